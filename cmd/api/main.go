@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 
 	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
 	"github.com/MSaiAswin/pet-clinic-management-system/cmd/logger"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/routes"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
 
 	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
 )
@@ -16,7 +19,13 @@ func init() {
 	l := logger.Get()
 	l.Info().Msg("Initializing application...")
 
-	err := initializers.ConnectDB()
+	err := utils.LoadKeys()
+	if err != nil {
+		l.Fatal().Err(err).Msg("Failed to load JWT signing keys")
+	}
+	l.Info().Msg("Loaded JWT signing keys")
+
+	err = initializers.ConnectDB()
 	if err != nil {
 		l.Fatal().Err(err).Msg("Failed to connect to the database")
 	}
@@ -28,6 +37,22 @@ func init() {
 	}
 	l.Info().Msg("Database migration completed successfully")
 
+	ctx := l.WithContext(context.Background())
+	err = service.NewRoleService().SeedRoles(ctx)
+	if err != nil {
+		l.Fatal().Err(err).Msg("Failed to seed the built-in roles")
+	}
+
+	err = service.NewCatalogService().SeedCatalog(ctx)
+	if err != nil {
+		l.Fatal().Err(err).Msg("Failed to seed the species and breed catalog")
+	}
+
+	err = service.NewUserService().BootstrapAdmin(ctx)
+	if err != nil {
+		l.Fatal().Err(err).Msg("Failed to bootstrap the admin account")
+	}
+
 }
 
 // @title Pet Clinic Management System API
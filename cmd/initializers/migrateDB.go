@@ -1,7 +1,11 @@
 package initializers
 
 import (
+	"errors"
+
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func MigrateDB() error {
@@ -10,7 +14,85 @@ func MigrateDB() error {
 		&model.User{},
 		&model.Pet{},
 		&model.Appointment{},
+		&model.Session{},
+		&model.UserToken{},
+		&model.PasswordHistory{},
+		&model.RecoveryCode{},
+		&model.Setting{},
+		&model.LoginAttempt{},
+		&model.Role{},
+		&model.RolePermission{},
+		&model.APIKey{},
+		&model.OIDCLoginState{},
+		&model.Impersonation{},
+		&model.ImpersonationEvent{},
+		&model.Vaccination{},
+		&model.VisitNote{},
+		&model.VisitNoteAddendum{},
+		&model.Prescription{},
+		&model.PetAlert{},
+		&model.VitalsMeasurement{},
+		&model.PetTransfer{},
+		&model.Household{},
+		&model.HouseholdMember{},
+		&model.HouseholdInvite{},
+		&model.AuditEvent{},
+		&model.Species{},
+		&model.Breed{},
+		&model.MergeLog{},
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := runOnce(model.SettingEmailVerificationBackfilled, backfillEmailVerification); err != nil {
+		return err
+	}
+	return runOnce(model.SettingOwnersReadGranted, grantOwnersRead)
+}
+
+// runOnce runs a data migration in a transaction unless a marker in the
+// settings table records that it already has, and sets the marker.
+func runOnce(key string, migrate func(tx *gorm.DB) error) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var marker model.Setting
+		err := tx.Where("key = ?", key).First(&marker).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := migrate(tx); err != nil {
+			return err
+		}
+		return tx.Create(&model.Setting{Key: key, Value: "true"}).Error
+	})
+}
+
+// backfillEmailVerification marks the accounts that existed before email
+// verification was introduced as verified, so that turning on
+// REQUIRE_EMAIL_VERIFICATION does not lock them out. Accounts that were ever
+// sent a verification link signed up afterwards and are left alone.
+func backfillEmailVerification(tx *gorm.DB) error {
+	sent := tx.Model(&model.UserToken{}).Select("1").
+		Where("user_tokens.user_id = users.id AND user_tokens.purpose = ?", model.TokenPurposeEmailVerification)
+	return tx.Model(&model.User{}).
+		Where("email_verified_at IS NULL AND NOT EXISTS (?)", sent).
+		UpdateColumn("email_verified_at", gorm.Expr("created_at")).Error
+}
 
-	return err
+// grantOwnersRead gives a staff role seeded before owners:read existed the
+// permission, so that staff can still look up owner profiles. A new staff
+// role gets it from its defaults.
+func grantOwnersRead(tx *gorm.DB) error {
+	var roles int64
+	if err := tx.Model(&model.Role{}).Where("name = ?", model.UserTypeStaff).Count(&roles).Error; err != nil {
+		return err
+	}
+	if roles == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.RolePermission{RoleName: model.UserTypeStaff, Permission: model.PermissionOwnersRead}).Error
 }
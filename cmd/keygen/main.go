@@ -0,0 +1,61 @@
+// Command keygen writes a new JWT signing key into the keys directory. The
+// file name is the kid, so rotating means generating a key with a new kid,
+// rolling it out to every instance and then pointing JWT_ACTIVE_KID at it.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func main() {
+	alg := flag.String("alg", "EdDSA", "signing algorithm: EdDSA or RS256")
+	kid := flag.String("kid", time.Now().UTC().Format("2006-01-02"), "key id, also used as the file name")
+	dir := flag.String("dir", "keys", "directory to write the key to")
+	flag.Parse()
+
+	var key interface{}
+	var err error
+	switch *alg {
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		err = fmt.Errorf("unsupported algorithm %q", *alg)
+	}
+	if err != nil {
+		fail(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		fail(err)
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		fail(err)
+	}
+	path := filepath.Join(*dir, *kid+".pem")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		fail(err)
+	}
+	defer file.Close()
+	if err := pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		fail(err)
+	}
+	fmt.Println(path)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "keygen:", err)
+	os.Exit(1)
+}
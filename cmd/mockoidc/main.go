@@ -0,0 +1,56 @@
+// Command mockoidc is a tiny OpenID Connect provider for trying out staff
+// single sign-on locally. It serves the same provider the tests use, from
+// internal/oidc/oidctest, and logs every visitor in as the user given on the
+// command line, so it must never be exposed beyond a developer machine.
+//
+// Start it and point the API at it:
+//
+//	go run ./cmd/mockoidc -groups clinic-staff
+//	OIDC_ISSUER=http://localhost:9000 OIDC_CLIENT_ID=pet-clinic \
+//	OIDC_REDIRECT_URL=http://localhost:8000/sso/oidc/callback \
+//	OIDC_ROLE_MAP=clinic-admins=admin,clinic-staff=staff
+//
+// then open http://localhost:8000/sso/oidc/login in a browser.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/logger"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL, must match OIDC_ISSUER")
+	clientID := flag.String("client-id", "pet-clinic", "expected client ID")
+	clientSecret := flag.String("client-secret", "", "expected client secret, empty for a public client")
+	sub := flag.String("sub", "mock-user-1", "subject of the logged in user")
+	email := flag.String("email", "jane.vet@clinic.test", "email of the logged in user")
+	name := flag.String("name", "Jane Vet", "name of the logged in user")
+	username := flag.String("username", "jane.vet", "preferred_username of the logged in user")
+	groups := flag.String("groups", "clinic-staff", "comma separated groups of the logged in user")
+	flag.Parse()
+
+	l := logger.Get()
+	provider := oidctest.NewProvider(strings.TrimSuffix(*issuer, "/"), *clientID, jwt.MapClaims{
+		"sub":                *sub,
+		"email":              *email,
+		"email_verified":     true,
+		"name":               *name,
+		"preferred_username": *username,
+		"groups":             strings.Split(*groups, ","),
+	})
+	provider.ClientSecret = *clientSecret
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider.ServeHTTP(w, r.WithContext(l.WithContext(r.Context())))
+	})
+
+	l.Info().Str("sub", *sub).Str("addr", *addr).Msg("Mock OIDC provider listening")
+	if err := http.ListenAndServe(*addr, handler); err != nil {
+		l.Fatal().Err(err).Msg("Mock OIDC provider stopped")
+	}
+}
@@ -0,0 +1,68 @@
+// Package authz resolves which permissions a role grants. Role permissions
+// live in the role_permissions table and are cached for a short time, so
+// edits made by an admin take effect on every instance within cacheTTL.
+package authz
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+)
+
+const cacheTTL = 30 * time.Second
+
+type cacheEntry struct {
+	permissions map[string]struct{}
+	expiresAt   time.Time
+}
+
+var (
+	mu    sync.RWMutex
+	cache = map[string]cacheEntry{}
+)
+
+// RoleHasPermission reports whether role grants permission. The admin role
+// grants every permission so that admins cannot lock themselves out.
+func RoleHasPermission(role, permission string) (bool, error) {
+	if role == model.UserTypeAdmin {
+		return true, nil
+	}
+	permissions, err := rolePermissions(role)
+	if err != nil {
+		return false, err
+	}
+	_, ok := permissions[permission]
+	return ok, nil
+}
+
+func rolePermissions(role string) (map[string]struct{}, error) {
+	mu.RLock()
+	entry, ok := cache[role]
+	mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	var names []string
+	tx := initializers.DB.Model(&model.RolePermission{}).Where("role_name = ?", role).Pluck("permission", &names)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	permissions := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		permissions[name] = struct{}{}
+	}
+	mu.Lock()
+	cache[role] = cacheEntry{permissions: permissions, expiresAt: time.Now().Add(cacheTTL)}
+	mu.Unlock()
+	return permissions, nil
+}
+
+// Invalidate drops the cached permissions of every role.
+func Invalidate() {
+	mu.Lock()
+	defer mu.Unlock()
+	cache = map[string]cacheEntry{}
+}
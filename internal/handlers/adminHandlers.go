@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" example:"staff"`
+}
+
+// ListUsersHandler godoc
+// @Summary List Users
+// @Description Lists users page by page, optionally filtered by a search query, role and disabled flag.
+// @Description Requires the users:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search in username, name, email and contact"
+// @Param role query string false "Filter by role"
+// @Param disabled query bool false "Filter by disabled flag"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Success 200 {object} service.UserPage "Page of users"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users [get]
+func (h *handlerService) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListUsersHandler")
+	query := r.URL.Query()
+	params := service.UserListParams{
+		Query: query.Get("q"),
+		Role:  query.Get("role"),
+	}
+	var err error
+	if v := query.Get("page"); v != "" {
+		if params.Page, err = strconv.Atoi(v); err != nil {
+			h.respond(w, errors.New("page is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("page_size"); v != "" {
+		if params.PageSize, err = strconv.Atoi(v); err != nil {
+			h.respond(w, errors.New("page_size is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("disabled"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			h.respond(w, errors.New("disabled is not valid"), http.StatusBadRequest)
+			return
+		}
+		params.Disabled = &disabled
+	}
+
+	page, err := h.userService.ListUsers(params, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list users")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, page, http.StatusOK)
+}
+
+// AdminGetUserHandler godoc
+// @Summary Get User
+// @Description Fetches any user by ID.
+// @Description Requires the users:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} model.User "User details"
+// @Failure 400 {object} ErrorResponse "Invalid user ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id} [get]
+func (h *handlerService) AdminGetUserHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside AdminGetUserHandler")
+	vars := mux.Vars(r)
+	userID, err := h.userIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	user, err := h.userService.GetUser(userID, r.Context())
+	if err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to fetch user")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, user, http.StatusOK)
+}
+
+// CreateUserHandler godoc
+// @Summary Create User
+// @Description Creates a user with any role, e.g. a staff or admin account.
+// @Description Requires the users:manage permission. Only admins may create admin accounts; anyone else may only hand out roles whose permissions they all hold.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.AdminCreateUserParams true "Create user request body"
+// @Success 201 {object} model.User "User created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users [post]
+func (h *handlerService) CreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreateUserHandler")
+	body := &service.AdminCreateUserParams{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Debug().Str("username", body.Username).Str("role", body.Role).Msg("Admin creating user")
+	user, err := h.userService.CreateUser(body, r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			h.respond(w, errors.New("username already exists"), http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrInvalidUserInput) || errors.As(err, &validators.PasswordPolicyError{}) || errors.Is(err, service.ErrInvalidRole) ||
+			errors.Is(err, validators.ErrInvalidEmail) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrRoleNotGrantable) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to create user")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", user.ID).Str("role", user.Role).Msg("User created by admin")
+	h.respond(w, user, http.StatusCreated)
+}
+
+// DisableUserHandler godoc
+// @Summary Disable User
+// @Description Disables a user account and revokes all of its sessions.
+// @Description Requires the users:manage permission, and every permission of the user's role unless the caller is an admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} model.User "User disabled"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/disable [post]
+func (h *handlerService) DisableUserHandler(w http.ResponseWriter, r *http.Request) {
+	h.setUserDisabled(w, r, true)
+}
+
+// EnableUserHandler godoc
+// @Summary Re-enable User
+// @Description Re-enables a disabled user account.
+// @Description Requires the users:manage permission, and every permission of the user's role unless the caller is an admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} model.User "User enabled"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/enable [post]
+func (h *handlerService) EnableUserHandler(w http.ResponseWriter, r *http.Request) {
+	h.setUserDisabled(w, r, false)
+}
+
+func (h *handlerService) setUserDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Bool("disabled", disabled).Msg("Inside setUserDisabled")
+	vars := mux.Vars(r)
+	userID, err := h.userIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	user, err := h.userService.SetUserDisabled(userID, disabled, r.Context())
+	if err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrCannotModifySelf) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrRoleNotGrantable) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to update disabled flag of user")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", userID).Bool("disabled", disabled).Msg("User disabled flag updated")
+	h.respond(w, user, http.StatusOK)
+}
+
+// ChangeUserRoleHandler godoc
+// @Summary Change User Role
+// @Description Changes the role of a user and revokes the user's sessions. Users cannot change their own role.
+// @Description Requires the users:manage permission. Only admins may grant or take away the admin role; anyone else must hold every permission of both the old and the new role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param body body ChangeRoleRequest true "Change role request body"
+// @Success 200 {object} model.User "Role changed"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/role [put]
+func (h *handlerService) ChangeUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ChangeUserRoleHandler")
+	vars := mux.Vars(r)
+	userID, err := h.userIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	user, err := h.userService.ChangeUserRole(userID, body.Role, r.Context())
+	if err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrInvalidRole) || errors.Is(err, service.ErrCannotModifySelf) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrRoleNotGrantable) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to change user role")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", userID).Str("role", body.Role).Msg("User role changed")
+	h.respond(w, user, http.StatusOK)
+}
+
+// UnlockUserHandler godoc
+// @Summary Unlock User
+// @Description Clears the failed login counter of a user and lifts any login lockout.
+// @Description Requires the users:manage permission, and every permission of the user's role unless the caller is an admin.
+// @Tags Admin
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Success 204 "User unlocked"
+// @Failure 400 {object} ErrorResponse "Invalid user ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/unlock [post]
+func (h *handlerService) UnlockUserHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UnlockUserHandler")
+	vars := mux.Vars(r)
+	userID, err := h.userIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.UnlockUser(userID, r.Context()); err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrRoleNotGrantable) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to unlock user")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", userID).Msg("User unlocked")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// UnlockIPHandler godoc
+// @Summary Unlock Client IP
+// @Description Clears the failed login counter of a client IP and lifts any login lockout.
+// @Description Requires the users:manage permission.
+// @Tags Admin
+// @Security BearerAuth
+// @Param ip path string true "Client IP address"
+// @Success 204 "IP unlocked"
+// @Failure 400 {object} ErrorResponse "Invalid IP address"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/ips/{ip}/unlock [post]
+func (h *handlerService) UnlockIPHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UnlockIPHandler")
+	ip := mux.Vars(r)["ip"]
+	if err := h.userService.UnlockIP(ip, r.Context()); err != nil {
+		if errors.Is(err, service.ErrInvalidIP) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to unlock IP")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Str("ip", ip).Msg("IP unlocked")
+	h.respond(w, nil, http.StatusNoContent)
+}
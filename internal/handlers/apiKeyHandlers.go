@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// CreateAPIKeyHandler godoc
+// @Summary Create API Key
+// @Description Creates a long-lived API key that acts as the given user, e.g. a kiosk or reporting account.
+// @Description The key is sent in the X-API-Key header and is only shown in this response.
+// @Description Scopes limit which permissions of the user's role the key may use, and at least one is required. Keys are refused on routes that need no permission, such as the account and owner routes.
+// @Description Requires the api_keys:manage permission. Callers can only grant scopes they hold themselves, and only for users whose role they could grant.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.APIKeyParams true "API key"
+// @Success 201 {object} service.CreatedAPIKey "API key created"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/api-keys [post]
+func (h *handlerService) CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreateAPIKeyHandler")
+	var body service.APIKeyParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	created, err := h.apiKeyService.CreateAPIKey(body, r.Context())
+	if err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrAPIKeyNameRequired) || errors.Is(err, service.ErrAPIKeyExpiryInPast) ||
+			errors.Is(err, service.ErrAPIKeyScopesRequired) || errors.As(err, &service.InvalidPermissionError{}) || errors.As(err, &service.ScopeNotGrantedError{}) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrScopeNotHeld) || errors.Is(err, service.ErrRoleNotGrantable) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to create API key")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("apiKeyID", created.APIKey.ID).Str("prefix", created.APIKey.Prefix).Uint("userID", created.APIKey.UserID).Msg("API key created")
+	h.respond(w, created, http.StatusCreated)
+}
+
+// ListAPIKeysHandler godoc
+// @Summary List API Keys
+// @Description Lists API keys, newest first, including revoked and expired ones.
+// @Description Requires the api_keys:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "Only keys of this user"
+// @Success 200 {array} model.APIKey "API keys"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/api-keys [get]
+func (h *handlerService) ListAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListAPIKeysHandler")
+	var userID uint
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		userID64, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			h.respond(w, errors.New("user_id is not valid"), http.StatusBadRequest)
+			return
+		}
+		userID = uint(userID64)
+	}
+	apiKeys, err := h.apiKeyService.ListAPIKeys(userID, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list API keys")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, apiKeys, http.StatusOK)
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary Revoke API Key
+// @Description Revokes an API key. Requests made with it are rejected from then on.
+// @Description Requires the api_keys:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} model.APIKey "API key revoked"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "API key not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/api-keys/{id} [delete]
+func (h *handlerService) RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RevokeAPIKeyHandler")
+	vars := mux.Vars(r)
+	apiKeyID, err := h.apiKeyIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	apiKey, err := h.apiKeyService.RevokeAPIKey(apiKeyID, r.Context())
+	if err != nil {
+		if errors.As(err, &service.APIKeyNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to revoke API key")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("apiKeyID", apiKey.ID).Str("prefix", apiKey.Prefix).Msg("API key revoked")
+	h.respond(w, apiKey, http.StatusOK)
+}
@@ -68,7 +68,7 @@ func (h *handlerService) GetAppointmentByIDHandler(w http.ResponseWriter, r *htt
 
 // CreateAppointmentHandler godoc
 // @Summary Create Appointment
-// @Description Creates a new appointment.
+// @Description Creates a new appointment. Household members need book access to the pet.
 // @Tags Appointment
 // @Accept json
 // @Produce json
@@ -77,6 +77,7 @@ func (h *handlerService) GetAppointmentByIDHandler(w http.ResponseWriter, r *htt
 // @Success 201 {object} model.Appointment "Appointment created successfully"
 // @Failure 400 {object} ErrorResponse "Invalid input"
 // @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 409 {object} ErrorResponse "Pet is deceased, transferred or archived"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // Failure 401 {object} ErrorResponse "Unauthorized"
 // @Router /appointments [post]
@@ -102,6 +103,9 @@ func (h *handlerService) CreateAppointmentHandler(w http.ResponseWriter, r *http
 		} else if errors.Is(err, service.ErrInvalidSlot) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
+		} else if errors.As(err, &service.PetNotActiveError{}) {
+			h.respond(w, err, http.StatusConflict)
+			return
 		} else if errors.As(err, &service.PetNotFoundError{}) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
@@ -131,6 +135,7 @@ func (h *handlerService) CreateAppointmentHandler(w http.ResponseWriter, r *http
 // @Failure 400 {object} ErrorResponse "Invalid input"
 // @Failure 404 {object} ErrorResponse "Appointment not found"
 // @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 409 {object} ErrorResponse "Pet is deceased, transferred or archived"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Router /appointments/{id} [put]
@@ -165,6 +170,9 @@ func (h *handlerService) UpdateAppointmentHandler(w http.ResponseWriter, r *http
 		} else if errors.Is(err, service.ErrInvalidSlot) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
+		} else if errors.As(err, &service.PetNotActiveError{}) {
+			h.respond(w, err, http.StatusConflict)
+			return
 		} else if errors.As(err, &service.PetNotFoundError{}) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
@@ -226,7 +234,7 @@ func (h *handlerService) DeleteAppointmentHandler(w http.ResponseWriter, r *http
 // GetUpcomingAppointmentsHandler godoc
 // @Summary Get Upcoming Appointments
 // @Description Fetches all upcoming appointments.
-// @Description This endpoint is restricted to staff users.
+// @Description Requires the appointments:read_all permission.
 // @Tags Appointment
 // @Produce json
 // @Security BearerAuth
@@ -267,7 +275,6 @@ func (h *handlerService) GetTodayAppointmentsHandler(w http.ResponseWriter, r *h
 // GetUpcomingAppointmentsByOwnerHandler godoc
 // @Summary Get Upcoming Appointments by Owner
 // @Description Fetches all upcoming appointments for the authenticated owner.
-// @Description This endpoint is restricted to staff users.
 // @Tags Appointment
 // @Produce json
 // @Security BearerAuth
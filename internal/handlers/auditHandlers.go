@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// ListAuditEventsHandler godoc
+// @Summary List Audit Events
+// @Description Lists the latest audit events, newest first, such as microchip lookups.
+// @Description Requires the audit_log:read permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param action query string false "Only events of this action, e.g. microchip.lookup"
+// @Param user_id query int false "Only events by this user"
+// @Param limit query int false "Number of events, defaults to 100 and at most 500"
+// @Success 200 {array} model.AuditEvent "Audit events"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/audit-events [get]
+func (h *handlerService) ListAuditEventsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListAuditEventsHandler")
+	query := r.URL.Query()
+	filter := service.AuditEventFilter{Action: query.Get("action")}
+	if v := query.Get("user_id"); v != "" {
+		userID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			h.respond(w, errors.New("user_id is not valid"), http.StatusBadRequest)
+			return
+		}
+		filter.UserID = uint(userID)
+	}
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 1 {
+			h.respond(w, errors.New("limit is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	events, err := h.auditService.ListAuditEvents(filter, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list audit events")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, events, http.StatusOK)
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// CatalogErrorResponse is returned for a species or breed that is not in the
+// catalog.
+type CatalogErrorResponse struct {
+	Error       string   `json:"error" example:"unknown breed \"golden retreiver\"; did you mean Golden Retriever?"`
+	Field       string   `json:"field,omitempty" example:"breed"`
+	Suggestions []string `json:"suggestions,omitempty" example:"Golden Retriever"`
+}
+
+// ListSpeciesHandler godoc
+// @Summary List Species
+// @Description Lists the species and breeds pets can be registered with, sorted by name. Names are matched case-insensitively and aliases are accepted too.
+// @Tags Catalog
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Species "Species with their breeds"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /catalog/species [get]
+func (h *handlerService) ListSpeciesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListSpeciesHandler")
+	species, err := h.catalogService.ListSpecies(r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list species")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, species, http.StatusOK)
+}
+
+// AddSpeciesHandler godoc
+// @Summary Add Species
+// @Description Adds a species to the catalog. Its name and aliases must not name another species.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.CatalogEntryParams true "Species"
+// @Success 201 {object} model.Species "Species added"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} ErrorResponse "Name or alias already in the catalog"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/species [post]
+func (h *handlerService) AddSpeciesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside AddSpeciesHandler")
+	var body service.CatalogEntryParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	species, err := h.catalogService.AddSpecies(body, r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	l.Info().Uint("speciesID", species.ID).Str("name", species.Name).Msg("Species added")
+	h.respond(w, species, http.StatusCreated)
+}
+
+// UpdateSpeciesHandler godoc
+// @Summary Update Species
+// @Description Renames a species or replaces its aliases. An empty name keeps the current one. Pets stored with the old name are renamed too.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Species ID"
+// @Param body body service.CatalogEntryParams true "Species"
+// @Success 200 {object} model.Species "Species updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Species not found"
+// @Failure 409 {object} ErrorResponse "Name or alias already in the catalog"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/species/{id} [put]
+func (h *handlerService) UpdateSpeciesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateSpeciesHandler")
+	vars := mux.Vars(r)
+	speciesID, err := h.speciesIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.CatalogEntryParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	species, err := h.catalogService.UpdateSpecies(speciesID, body, r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	l.Info().Uint("speciesID", species.ID).Str("name", species.Name).Msg("Species updated")
+	h.respond(w, species, http.StatusOK)
+}
+
+// AddBreedHandler godoc
+// @Summary Add Breed
+// @Description Adds a breed to a species. Its name and aliases must not name another breed of the species.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Species ID"
+// @Param body body service.CatalogEntryParams true "Breed"
+// @Success 201 {object} model.Breed "Breed added"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Species not found"
+// @Failure 409 {object} ErrorResponse "Name or alias already in the catalog"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/species/{id}/breeds [post]
+func (h *handlerService) AddBreedHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside AddBreedHandler")
+	vars := mux.Vars(r)
+	speciesID, err := h.speciesIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.CatalogEntryParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	breed, err := h.catalogService.AddBreed(speciesID, body, r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	l.Info().Uint("breedID", breed.ID).Str("name", breed.Name).Msg("Breed added")
+	h.respond(w, breed, http.StatusCreated)
+}
+
+// UpdateBreedHandler godoc
+// @Summary Update Breed
+// @Description Renames a breed or replaces its aliases. An empty name keeps the current one. Pets of the species stored with the old name are renamed too.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Species ID"
+// @Param breedID path int true "Breed ID"
+// @Param body body service.CatalogEntryParams true "Breed"
+// @Success 200 {object} model.Breed "Breed updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Species or breed not found"
+// @Failure 409 {object} ErrorResponse "Name or alias already in the catalog"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/species/{id}/breeds/{breedID} [put]
+func (h *handlerService) UpdateBreedHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateBreedHandler")
+	vars := mux.Vars(r)
+	speciesID, breedID, err := h.breedIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.CatalogEntryParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	breed, err := h.catalogService.UpdateBreed(speciesID, breedID, body, r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	l.Info().Uint("breedID", breed.ID).Str("name", breed.Name).Msg("Breed updated")
+	h.respond(w, breed, http.StatusOK)
+}
+
+// ListUnmappedPetsHandler godoc
+// @Summary List Unmapped Pet Values
+// @Description Lists the species and breed combinations stored on pets that are not catalog entries, e.g. free text saved before the catalog existed, with how many pets use them and the entries they most likely mean.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} service.UnmappedPetValues "Unmapped values"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/unmapped [get]
+func (h *handlerService) ListUnmappedPetsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListUnmappedPetsHandler")
+	values, err := h.catalogService.ListUnmappedPets(r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	h.respond(w, values, http.StatusOK)
+}
+
+// RemapPetsHandler godoc
+// @Summary Remap Pets
+// @Description Moves the pets stored with from_species, and from_breed when given, onto a catalog species and breed.
+// @Description With add_aliases the old values are accepted as aliases of the entries from now on.
+// @Description Requires the catalog:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.RemapPetsParams true "Remap"
+// @Success 200 {object} service.RemapPetsResult "Pets remapped"
+// @Failure 400 {object} CatalogErrorResponse "Invalid input or unknown target"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/catalog/remap [post]
+func (h *handlerService) RemapPetsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RemapPetsHandler")
+	var body service.RemapPetsParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	result, err := h.catalogService.RemapPets(body, r.Context())
+	if err != nil {
+		h.respondCatalogAdminError(w, r, err)
+		return
+	}
+	h.respond(w, result, http.StatusOK)
+}
+
+// respondCatalogError responds to a species or breed missing from the
+// catalog with the suggested entries and reports whether err was one.
+func (h *handlerService) respondCatalogError(w http.ResponseWriter, err error) bool {
+	var unknown service.UnknownCatalogEntryError
+	if !errors.As(err, &unknown) {
+		return false
+	}
+	h.respond(w, CatalogErrorResponse{
+		Error:       unknown.Error(),
+		Field:       unknown.Field,
+		Suggestions: unknown.Suggestions,
+	}, http.StatusBadRequest)
+	return true
+}
+
+func (h *handlerService) respondCatalogAdminError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if h.respondCatalogError(w, err) {
+		return
+	} else if errors.As(err, &service.SpeciesNotFoundError{}) || errors.As(err, &service.BreedNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, service.ErrCatalogEntryExists) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrCatalogNameRequired) || errors.Is(err, service.ErrRemapSourceRequired) ||
+		errors.Is(err, service.ErrRemapBreedRequired) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage catalog")
+	h.respond(w, err, http.StatusInternalServerError)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// FindDuplicatesHandler godoc
+// @Summary Find Duplicates
+// @Description Flags owners and pets that are likely recorded twice, most likely first.
+// @Description Owners are compared on name, phone number and email; pets on owner, name and breed, and only within the same species. Every pair comes with the reasons it was flagged.
+// @Description Requires the records:merge permission.
+// @Tags Duplicates
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Pairs per record type, defaults to 50 and at most 200"
+// @Success 200 {object} service.DuplicateReport "Likely duplicates"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/duplicates [get]
+func (h *handlerService) FindDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside FindDuplicatesHandler")
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			h.respond(w, errors.New("limit is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	report, err := h.duplicateService.FindDuplicates(limit, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to find duplicates")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, report, http.StatusOK)
+}
+
+// MergeUsersHandler godoc
+// @Summary Merge Owners
+// @Description Merges a duplicate owner account into the one that is kept, in a single transaction.
+// @Description The duplicate's pets, with their appointments and documents, transfers and household memberships move to the kept owner, who also takes over its name and phone number if they have none.
+// @Description Where both are members of a household, the kept owner gets the higher of the two access levels.
+// @Description The duplicate is then signed out and deleted. The merge log keeps a copy of it.
+// @Description Requires the records:merge permission.
+// @Tags Duplicates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.MergeParams true "Duplicate and kept owner"
+// @Success 200 {object} model.MergeLog "Merge log entry"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 409 {object} ErrorResponse "Records cannot be merged"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/merges/users [post]
+func (h *handlerService) MergeUsersHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside MergeUsersHandler")
+	var body service.MergeParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	mergeLog, err := h.duplicateService.MergeUsers(body, r.Context())
+	if err != nil {
+		h.respondMergeError(w, r, err)
+		return
+	}
+	h.respond(w, mergeLog, http.StatusOK)
+}
+
+// MergePetsHandler godoc
+// @Summary Merge Pets
+// @Description Merges a duplicate pet into the one that is kept, in a single transaction. Both must be of the same species and must not have different microchips.
+// @Description The duplicate's appointments, vaccinations, prescriptions, alerts, vitals, transfers and documents move to the kept pet, which also takes over its microchip and photo if it has none. Medical histories are combined.
+// @Description The kept pet keeps its status: if it is deceased, transferred or archived, the duplicate's future appointments and pending transfers are cancelled instead of moved. An active pet cannot be merged into an archived one.
+// @Description The duplicate is then deleted. The merge log keeps a copy of it.
+// @Description Requires the records:merge permission.
+// @Tags Duplicates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.MergeParams true "Duplicate and kept pet"
+// @Success 200 {object} model.MergeLog "Merge log entry"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 409 {object} ErrorResponse "Records cannot be merged"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/merges/pets [post]
+func (h *handlerService) MergePetsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside MergePetsHandler")
+	var body service.MergeParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	mergeLog, err := h.duplicateService.MergePets(body, r.Context())
+	if err != nil {
+		h.respondMergeError(w, r, err)
+		return
+	}
+	h.respond(w, mergeLog, http.StatusOK)
+}
+
+// ListMergeLogsHandler godoc
+// @Summary List Merges
+// @Description Lists the latest merges of duplicate owners and pets, newest first, with a copy of each merged record and how many related records were moved.
+// @Description Requires the records:merge permission.
+// @Tags Duplicates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.MergeLog "Merge log"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/merges [get]
+func (h *handlerService) ListMergeLogsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListMergeLogsHandler")
+	logs, err := h.duplicateService.ListMergeLogs(r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list merges")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, logs, http.StatusOK)
+}
+
+func (h *handlerService) respondMergeError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.UserNotFoundError{}) || errors.As(err, &service.PetNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, service.ErrMergeSameRecord) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, service.ErrMergeNotOwner) || errors.Is(err, service.ErrMergeSpeciesMismatch) ||
+		errors.Is(err, service.ErrMergeMicrochipConflict) || errors.Is(err, service.ErrMergeDocumentConflict) ||
+		errors.Is(err, service.ErrMergeIntoArchived) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to merge records")
+	h.respond(w, err, http.StatusInternalServerError)
+}
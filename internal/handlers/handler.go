@@ -5,18 +5,69 @@ import (
 )
 
 type handlerService struct {
-	petService         *service.PetService
-	appointmentService *service.AppointmentService
-	userService        *service.UserService
+	petService           *service.PetService
+	appointmentService   *service.AppointmentService
+	userService          *service.UserService
+	sessionService       *service.SessionService
+	settingService       *service.SettingService
+	roleService          *service.RoleService
+	apiKeyService        *service.APIKeyService
+	impersonationService *service.ImpersonationService
+	vaccinationService   *service.VaccinationService
+	visitNoteService     *service.VisitNoteService
+	prescriptionService  *service.PrescriptionService
+	petAlertService      *service.PetAlertService
+	vitalsService        *service.VitalsService
+	petTransferService   *service.PetTransferService
+	householdService     *service.HouseholdService
+	microchipService     *service.MicrochipService
+	auditService         *service.AuditService
+	catalogService       *service.CatalogService
+	searchService        *service.SearchService
+	duplicateService     *service.DuplicateService
 }
 
 func NewService() *handlerService {
 	petService := service.NewPetService()
 	appointmentService := service.NewAppointmentService()
 	userService := service.NewUserService()
+	sessionService := service.NewSessionService()
+	settingService := service.NewSettingService()
+	roleService := service.NewRoleService()
+	apiKeyService := service.NewAPIKeyService()
+	impersonationService := service.NewImpersonationService()
+	vaccinationService := service.NewVaccinationService()
+	visitNoteService := service.NewVisitNoteService()
+	prescriptionService := service.NewPrescriptionService()
+	petAlertService := service.NewPetAlertService()
+	vitalsService := service.NewVitalsService()
+	petTransferService := service.NewPetTransferService()
+	householdService := service.NewHouseholdService()
+	microchipService := service.NewMicrochipService()
+	auditService := service.NewAuditService()
+	catalogService := service.NewCatalogService()
+	searchService := service.NewSearchService()
+	duplicateService := service.NewDuplicateService()
 	return &handlerService{
-		petService:         petService,
-		appointmentService: appointmentService,
-		userService:        userService,
+		petService:           petService,
+		appointmentService:   appointmentService,
+		userService:          userService,
+		sessionService:       sessionService,
+		settingService:       settingService,
+		roleService:          roleService,
+		apiKeyService:        apiKeyService,
+		impersonationService: impersonationService,
+		vaccinationService:   vaccinationService,
+		visitNoteService:     visitNoteService,
+		prescriptionService:  prescriptionService,
+		petAlertService:      petAlertService,
+		vitalsService:        vitalsService,
+		petTransferService:   petTransferService,
+		householdService:     householdService,
+		microchipService:     microchipService,
+		auditService:         auditService,
+		catalogService:       catalogService,
+		searchService:        searchService,
+		duplicateService:     duplicateService,
 	}
 }
@@ -53,3 +53,213 @@ func (h *handlerService) appointmentIDValidate(vars *map[string]string) (uint, e
 	}
 	return appointmentID, nil
 }
+
+func (h *handlerService) userIDValidate(vars *map[string]string) (uint, error) {
+	userIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("user id not provided")
+	}
+	userID64, err := strconv.ParseUint(userIDStr, 10, 32)
+	userID := uint(userID64)
+	if err != nil {
+		return 0, errors.New("user id is not valid")
+	}
+	return userID, nil
+}
+
+func (h *handlerService) apiKeyIDValidate(vars *map[string]string) (uint, error) {
+	apiKeyIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("API key id not provided")
+	}
+	apiKeyID64, err := strconv.ParseUint(apiKeyIDStr, 10, 32)
+	apiKeyID := uint(apiKeyID64)
+	if err != nil {
+		return 0, errors.New("API key id is not valid")
+	}
+	return apiKeyID, nil
+}
+
+func (h *handlerService) impersonationIDValidate(vars *map[string]string) (uint, error) {
+	impersonationIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("impersonation id not provided")
+	}
+	impersonationID64, err := strconv.ParseUint(impersonationIDStr, 10, 32)
+	impersonationID := uint(impersonationID64)
+	if err != nil {
+		return 0, errors.New("impersonation id is not valid")
+	}
+	return impersonationID, nil
+}
+
+func (h *handlerService) vaccinationIDValidate(vars *map[string]string) (uint, uint, error) {
+	petID, err := h.petIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	vaccinationIDStr, ok := (*vars)["vaccinationID"]
+	if !ok {
+		return 0, 0, errors.New("vaccination id not provided")
+	}
+	vaccinationID64, err := strconv.ParseUint(vaccinationIDStr, 10, 32)
+	vaccinationID := uint(vaccinationID64)
+	if err != nil {
+		return 0, 0, errors.New("vaccination id is not valid")
+	}
+	return petID, vaccinationID, nil
+}
+
+func (h *handlerService) prescriptionIDValidate(vars *map[string]string) (uint, uint, error) {
+	petID, err := h.petIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	prescriptionIDStr, ok := (*vars)["prescriptionID"]
+	if !ok {
+		return 0, 0, errors.New("prescription id not provided")
+	}
+	prescriptionID64, err := strconv.ParseUint(prescriptionIDStr, 10, 32)
+	prescriptionID := uint(prescriptionID64)
+	if err != nil {
+		return 0, 0, errors.New("prescription id is not valid")
+	}
+	return petID, prescriptionID, nil
+}
+
+func (h *handlerService) petAlertIDValidate(vars *map[string]string) (uint, uint, error) {
+	petID, err := h.petIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	alertIDStr, ok := (*vars)["alertID"]
+	if !ok {
+		return 0, 0, errors.New("alert id not provided")
+	}
+	alertID64, err := strconv.ParseUint(alertIDStr, 10, 32)
+	alertID := uint(alertID64)
+	if err != nil {
+		return 0, 0, errors.New("alert id is not valid")
+	}
+	return petID, alertID, nil
+}
+
+func (h *handlerService) measurementIDValidate(vars *map[string]string) (uint, uint, error) {
+	petID, err := h.petIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	measurementIDStr, ok := (*vars)["measurementID"]
+	if !ok {
+		return 0, 0, errors.New("measurement id not provided")
+	}
+	measurementID64, err := strconv.ParseUint(measurementIDStr, 10, 32)
+	measurementID := uint(measurementID64)
+	if err != nil {
+		return 0, 0, errors.New("measurement id is not valid")
+	}
+	return petID, measurementID, nil
+}
+
+func (h *handlerService) petTransferIDValidate(vars *map[string]string) (uint, error) {
+	transferIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("transfer id not provided")
+	}
+	transferID64, err := strconv.ParseUint(transferIDStr, 10, 32)
+	transferID := uint(transferID64)
+	if err != nil {
+		return 0, errors.New("transfer id is not valid")
+	}
+	return transferID, nil
+}
+
+func (h *handlerService) householdIDValidate(vars *map[string]string) (uint, error) {
+	householdIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("household id not provided")
+	}
+	householdID64, err := strconv.ParseUint(householdIDStr, 10, 32)
+	householdID := uint(householdID64)
+	if err != nil {
+		return 0, errors.New("household id is not valid")
+	}
+	return householdID, nil
+}
+
+func (h *handlerService) householdMemberIDValidate(vars *map[string]string) (uint, uint, error) {
+	householdID, err := h.householdIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	memberIDStr, ok := (*vars)["memberID"]
+	if !ok {
+		return 0, 0, errors.New("member id not provided")
+	}
+	memberID64, err := strconv.ParseUint(memberIDStr, 10, 32)
+	memberID := uint(memberID64)
+	if err != nil {
+		return 0, 0, errors.New("member id is not valid")
+	}
+	return householdID, memberID, nil
+}
+
+func (h *handlerService) householdInviteIDValidate(vars *map[string]string) (uint, uint, error) {
+	householdID, err := h.householdIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	inviteIDStr, ok := (*vars)["inviteID"]
+	if !ok {
+		return 0, 0, errors.New("invite id not provided")
+	}
+	inviteID64, err := strconv.ParseUint(inviteIDStr, 10, 32)
+	inviteID := uint(inviteID64)
+	if err != nil {
+		return 0, 0, errors.New("invite id is not valid")
+	}
+	return householdID, inviteID, nil
+}
+
+func (h *handlerService) inviteIDValidate(vars *map[string]string) (uint, error) {
+	inviteIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("invite id not provided")
+	}
+	inviteID64, err := strconv.ParseUint(inviteIDStr, 10, 32)
+	inviteID := uint(inviteID64)
+	if err != nil {
+		return 0, errors.New("invite id is not valid")
+	}
+	return inviteID, nil
+}
+
+func (h *handlerService) speciesIDValidate(vars *map[string]string) (uint, error) {
+	speciesIDStr, ok := (*vars)["id"]
+	if !ok {
+		return 0, errors.New("species id not provided")
+	}
+	speciesID64, err := strconv.ParseUint(speciesIDStr, 10, 32)
+	speciesID := uint(speciesID64)
+	if err != nil {
+		return 0, errors.New("species id is not valid")
+	}
+	return speciesID, nil
+}
+
+func (h *handlerService) breedIDValidate(vars *map[string]string) (uint, uint, error) {
+	speciesID, err := h.speciesIDValidate(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	breedIDStr, ok := (*vars)["breedID"]
+	if !ok {
+		return 0, 0, errors.New("breed id not provided")
+	}
+	breedID64, err := strconv.ParseUint(breedIDStr, 10, 32)
+	breedID := uint(breedID64)
+	if err != nil {
+		return 0, 0, errors.New("breed id is not valid")
+	}
+	return speciesID, breedID, nil
+}
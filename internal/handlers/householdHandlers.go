@@ -0,0 +1,498 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// CreateHouseholdHandler godoc
+// @Summary Create Household
+// @Description Creates a household to share pets with, e.g. a partner or a pet sitter. The caller becomes its first member with manage access.
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.HouseholdParams true "Household"
+// @Success 201 {object} model.Household "Household created"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households [post]
+func (h *handlerService) CreateHouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreateHouseholdHandler")
+	var body service.HouseholdParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	household, err := h.householdService.CreateHousehold(body, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", household.ID).Msg("Household created")
+	h.respond(w, household, http.StatusCreated)
+}
+
+// ListMyHouseholdsHandler godoc
+// @Summary List My Households
+// @Description Lists the households the authenticated user belongs to, with their members.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Household "Households"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households [get]
+func (h *handlerService) ListMyHouseholdsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListMyHouseholdsHandler")
+	households, err := h.householdService.ListMyHouseholds(r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	h.respond(w, households, http.StatusOK)
+}
+
+// GetHouseholdHandler godoc
+// @Summary Get Household
+// @Description Fetches a household with its members. Members and staff with the pets:read_all permission may view it.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Success 200 {object} model.Household "Household"
+// @Failure 400 {object} ErrorResponse "Invalid household ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a member"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id} [get]
+func (h *handlerService) GetHouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetHouseholdHandler")
+	vars := mux.Vars(r)
+	householdID, err := h.householdIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	household, err := h.householdService.GetHousehold(householdID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	h.respond(w, household, http.StatusOK)
+}
+
+// UpdateHouseholdHandler godoc
+// @Summary Rename Household
+// @Description Renames a household. Requires manage access to the household.
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param body body service.HouseholdParams true "Household"
+// @Success 200 {object} model.Household "Household updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id} [put]
+func (h *handlerService) UpdateHouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateHouseholdHandler")
+	vars := mux.Vars(r)
+	householdID, err := h.householdIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.HouseholdParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	household, err := h.householdService.UpdateHousehold(householdID, body, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	h.respond(w, household, http.StatusOK)
+}
+
+// DeleteHouseholdHandler godoc
+// @Summary Delete Household
+// @Description Dissolves a household. Its pets stay with their owners and are no longer shared. Requires manage access to the household.
+// @Tags Household
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Success 204 "Household deleted"
+// @Failure 400 {object} ErrorResponse "Invalid household ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id} [delete]
+func (h *handlerService) DeleteHouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeleteHouseholdHandler")
+	vars := mux.Vars(r)
+	householdID, err := h.householdIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.householdService.DeleteHousehold(householdID, r.Context()); err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", householdID).Msg("Household deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// InviteHouseholdMemberHandler godoc
+// @Summary Invite Household Member
+// @Description Invites whoever has the account with the given email to a household. They are notified by email and become a member once they accept.
+// @Description The response is the same whether or not an account uses the email; the member's name only shows in the household after they accept.
+// @Description Members can see the household's pets with view access, also book and cancel their appointments with book access, and edit the pets and the household with manage access.
+// @Description Requires manage access to the household.
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param body body service.HouseholdMemberParams true "Invitee"
+// @Success 201 {object} model.HouseholdInvite "Invite sent"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 409 {object} ErrorResponse "Already a member or already invited"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id}/invites [post]
+func (h *handlerService) InviteHouseholdMemberHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside InviteHouseholdMemberHandler")
+	vars := mux.Vars(r)
+	householdID, err := h.householdIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.HouseholdMemberParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	invite, err := h.householdService.InviteHouseholdMember(householdID, body, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", householdID).Uint("inviteID", invite.ID).Str("access", invite.Access).Msg("Household member invited")
+	h.respond(w, invite, http.StatusCreated)
+}
+
+// ListHouseholdInvitesHandler godoc
+// @Summary List Household Invites
+// @Description Lists the pending invites of a household, newest first.
+// @Description Requires manage access to the household.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Success 200 {array} model.HouseholdInvite "Pending invites"
+// @Failure 400 {object} ErrorResponse "Invalid household ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id}/invites [get]
+func (h *handlerService) ListHouseholdInvitesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListHouseholdInvitesHandler")
+	vars := mux.Vars(r)
+	householdID, err := h.householdIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	invites, err := h.householdService.ListHouseholdInvites(householdID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	h.respond(w, invites, http.StatusOK)
+}
+
+// CancelHouseholdInviteHandler godoc
+// @Summary Cancel Household Invite
+// @Description Withdraws a pending invite.
+// @Description Requires manage access to the household.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param inviteID path int true "Invite ID"
+// @Success 200 {object} model.HouseholdInvite "Invite cancelled"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household or invite not found"
+// @Failure 409 {object} ErrorResponse "Invite no longer pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id}/invites/{inviteID}/cancel [post]
+func (h *handlerService) CancelHouseholdInviteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CancelHouseholdInviteHandler")
+	vars := mux.Vars(r)
+	householdID, inviteID, err := h.householdInviteIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	invite, err := h.householdService.CancelHouseholdInvite(householdID, inviteID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", householdID).Uint("inviteID", inviteID).Msg("Household invite cancelled")
+	h.respond(w, invite, http.StatusOK)
+}
+
+// ListMyHouseholdInvitesHandler godoc
+// @Summary List My Household Invites
+// @Description Lists the pending household invites sent to the authenticated user's email, newest first. Invites only show once the email is verified.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.HouseholdInvite "Pending invites"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /household-invites [get]
+func (h *handlerService) ListMyHouseholdInvitesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListMyHouseholdInvitesHandler")
+	invites, err := h.householdService.ListMyHouseholdInvites(r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	h.respond(w, invites, http.StatusOK)
+}
+
+// AcceptHouseholdInviteHandler godoc
+// @Summary Accept Household Invite
+// @Description Accepts an invite sent to the authenticated user's verified email, who joins the household with the access it offers.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invite ID"
+// @Success 200 {object} model.HouseholdMember "Joined the household"
+// @Failure 400 {object} ErrorResponse "Invalid invite ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Email not verified"
+// @Failure 404 {object} ErrorResponse "Invite or household not found"
+// @Failure 409 {object} ErrorResponse "Invite no longer pending or already a member"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /household-invites/{id}/accept [post]
+func (h *handlerService) AcceptHouseholdInviteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside AcceptHouseholdInviteHandler")
+	vars := mux.Vars(r)
+	inviteID, err := h.inviteIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	member, err := h.householdService.AcceptHouseholdInvite(inviteID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", member.HouseholdID).Uint("inviteID", inviteID).Str("access", member.Access).Msg("Household invite accepted")
+	h.respond(w, member, http.StatusOK)
+}
+
+// DeclineHouseholdInviteHandler godoc
+// @Summary Decline Household Invite
+// @Description Declines an invite sent to the authenticated user's verified email.
+// @Tags Household
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Invite ID"
+// @Success 200 {object} model.HouseholdInvite "Invite declined"
+// @Failure 400 {object} ErrorResponse "Invalid invite ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Email not verified"
+// @Failure 404 {object} ErrorResponse "Invite not found"
+// @Failure 409 {object} ErrorResponse "Invite no longer pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /household-invites/{id}/decline [post]
+func (h *handlerService) DeclineHouseholdInviteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeclineHouseholdInviteHandler")
+	vars := mux.Vars(r)
+	inviteID, err := h.inviteIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	invite, err := h.householdService.DeclineHouseholdInvite(inviteID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", invite.HouseholdID).Uint("inviteID", inviteID).Msg("Household invite declined")
+	h.respond(w, invite, http.StatusOK)
+}
+
+// UpdateHouseholdMemberHandler godoc
+// @Summary Change Household Member Access
+// @Description Changes a member's access level. A household always keeps at least one member with manage access.
+// @Description Requires manage access to the household.
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param memberID path int true "Member ID"
+// @Param body body service.HouseholdMemberParams true "Access"
+// @Success 200 {object} model.HouseholdMember "Member updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household or member not found"
+// @Failure 409 {object} ErrorResponse "Last member with manage access"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id}/members/{memberID} [put]
+func (h *handlerService) UpdateHouseholdMemberHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateHouseholdMemberHandler")
+	vars := mux.Vars(r)
+	householdID, memberID, err := h.householdMemberIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.HouseholdMemberParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	member, err := h.householdService.UpdateHouseholdMember(householdID, memberID, body, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", householdID).Uint("memberID", memberID).Str("access", member.Access).Msg("Household member updated")
+	h.respond(w, member, http.StatusOK)
+}
+
+// RemoveHouseholdMemberHandler godoc
+// @Summary Remove Household Member
+// @Description Removes a member from a household; members may also remove themselves to leave it.
+// @Description Pets the member owns are no longer shared with the household. Removing anyone else requires manage access.
+// @Tags Household
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param memberID path int true "Member ID"
+// @Success 204 "Member removed"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No manage access"
+// @Failure 404 {object} ErrorResponse "Household or member not found"
+// @Failure 409 {object} ErrorResponse "Last member with manage access"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /households/{id}/members/{memberID} [delete]
+func (h *handlerService) RemoveHouseholdMemberHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RemoveHouseholdMemberHandler")
+	vars := mux.Vars(r)
+	householdID, memberID, err := h.householdMemberIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.householdService.RemoveHouseholdMember(householdID, memberID, r.Context()); err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("householdID", householdID).Uint("memberID", memberID).Msg("Household member removed")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// SetPetHouseholdHandler godoc
+// @Summary Share Pet With Household
+// @Description Shares a pet with one of its owner's households, or stops sharing it when household_id is null.
+// @Description Only the pet's owner and staff with the pets:write_all permission may do so.
+// @Tags Household
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.PetHouseholdParams true "Household"
+// @Success 200 {object} model.Pet "Pet updated"
+// @Failure 400 {object} ErrorResponse "Invalid input or owner not a member"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet or household not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/household [put]
+func (h *handlerService) SetPetHouseholdHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SetPetHouseholdHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PetHouseholdParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	pet, err := h.householdService.SetPetHousehold(petID, body.HouseholdID, r.Context())
+	if err != nil {
+		h.respondHouseholdError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Msg("Pet household updated")
+	h.respond(w, pet, http.StatusOK)
+}
+
+func (h *handlerService) respondHouseholdError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.HouseholdNotFoundError{}) || errors.As(err, &service.HouseholdMemberNotFoundError{}) ||
+		errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.HouseholdInviteNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) || errors.Is(err, service.ErrEmailNotVerified) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrAlreadyHouseholdMember) || errors.Is(err, service.ErrLastHouseholdManager) ||
+		errors.Is(err, service.ErrHouseholdInvitePending) || errors.Is(err, service.ErrHouseholdInviteNotPending) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrHouseholdNameRequired) || errors.Is(err, service.ErrInvalidHouseholdAccess) ||
+		errors.Is(err, service.ErrOwnerNotHouseholdMember) || errors.Is(err, validators.ErrInvalidEmail) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage household")
+	h.respond(w, err, http.StatusInternalServerError)
+}
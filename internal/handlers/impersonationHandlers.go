@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// StartImpersonationHandler godoc
+// @Summary Impersonate Owner
+// @Description Returns an access token that acts as the given owner, so staff can see exactly what the owner sees.
+// @Description The token cannot be refreshed, ends when the staff member logs out, and every request made with it is audited.
+// @Description Changing the owner's password, two-factor settings or deleting the account is not possible while impersonating.
+// @Description Requires the users:impersonate permission.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.ImpersonationParams true "Impersonation request body"
+// @Success 201 {object} service.ImpersonationToken "Impersonation started"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /impersonations [post]
+func (h *handlerService) StartImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside StartImpersonationHandler")
+	var body service.ImpersonationParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	token, err := h.impersonationService.StartImpersonation(body, r.Context())
+	if err != nil {
+		if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrImpersonationReasonRequired) || errors.Is(err, service.ErrCanOnlyImpersonateOwners) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrAlreadyImpersonating) || errors.Is(err, service.ErrImpersonationNeedsSession) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to start impersonation")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().
+		Uint("impersonationID", token.Impersonation.ID).
+		Uint("impersonatorID", token.Impersonation.ImpersonatorID).
+		Uint("userID", token.Impersonation.UserID).
+		Str("reason", token.Impersonation.Reason).
+		Msg("Impersonation started")
+	h.respond(w, token, http.StatusCreated)
+}
+
+// EndImpersonationHandler godoc
+// @Summary End Impersonation
+// @Description Ends the impersonation the request is made under. The impersonation token stops working.
+// @Tags User
+// @Security BearerAuth
+// @Success 204 "Impersonation ended"
+// @Failure 400 {object} ErrorResponse "Not impersonating"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /impersonations/end [post]
+func (h *handlerService) EndImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside EndImpersonationHandler")
+	if err := h.impersonationService.EndImpersonation(r.Context()); err != nil {
+		if errors.Is(err, service.ErrNotImpersonating) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to end impersonation")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Msg("Impersonation ended")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// ListImpersonationsHandler godoc
+// @Summary List Impersonations
+// @Description Lists impersonations page by page, newest first, optionally filtered by staff member or owner.
+// @Description Requires the users:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param impersonator_id query int false "Filter by staff member"
+// @Param user_id query int false "Filter by impersonated owner"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Success 200 {object} service.ImpersonationPage "Page of impersonations"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/impersonations [get]
+func (h *handlerService) ListImpersonationsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListImpersonationsHandler")
+	query := r.URL.Query()
+	var params service.ImpersonationListParams
+	for name, target := range map[string]*uint{"impersonator_id": &params.ImpersonatorID, "user_id": &params.UserID} {
+		if v := query.Get(name); v != "" {
+			id, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				h.respond(w, errors.New(name+" is not valid"), http.StatusBadRequest)
+				return
+			}
+			*target = uint(id)
+		}
+	}
+	var err error
+	if v := query.Get("page"); v != "" {
+		if params.Page, err = strconv.Atoi(v); err != nil {
+			h.respond(w, errors.New("page is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("page_size"); v != "" {
+		if params.PageSize, err = strconv.Atoi(v); err != nil {
+			h.respond(w, errors.New("page_size is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := h.impersonationService.ListImpersonations(params, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list impersonations")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, page, http.StatusOK)
+}
+
+// GetImpersonationHandler godoc
+// @Summary Get Impersonation
+// @Description Fetches an impersonation with every request made under it.
+// @Description Requires the users:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Impersonation ID"
+// @Success 200 {object} model.Impersonation "Impersonation with its audit trail"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Impersonation not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/impersonations/{id} [get]
+func (h *handlerService) GetImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetImpersonationHandler")
+	vars := mux.Vars(r)
+	impersonationID, err := h.impersonationIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	impersonation, err := h.impersonationService.GetImpersonation(impersonationID, r.Context())
+	if err != nil {
+		if errors.As(err, &service.ImpersonationNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to fetch impersonation")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, impersonation, http.StatusOK)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// JWKSHandler godoc
+// @Summary JSON Web Key Set
+// @Description Returns the public keys access tokens are signed with, so that other services can verify them.
+// @Description Tokens carry the key id in their kid header.
+// @Tags User
+// @Produce json
+// @Success 200 {object} utils.JWKS "Public signing keys"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /.well-known/jwks.json [get]
+func (h *handlerService) JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside JWKSHandler")
+	jwks, err := utils.PublicJWKS()
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to build JWKS")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	h.respond(w, jwks, http.StatusOK)
+}
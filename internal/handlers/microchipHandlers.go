@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// LookupMicrochipHandler godoc
+// @Summary Look Up Microchip
+// @Description Finds the pet registered with a scanned microchip, e.g. when a stray is brought in, together with its owner's contact details.
+// @Description Spaces and dashes in the number are ignored. Every lookup is recorded in the audit log.
+// @Description Requires the microchips:lookup permission.
+// @Tags Microchip
+// @Produce json
+// @Security BearerAuth
+// @Param number path string true "15-digit microchip number"
+// @Success 200 {object} service.MicrochipLookup "Pet and owner"
+// @Failure 400 {object} ErrorResponse "Invalid microchip number"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "No pet registered with the microchip"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/microchips/{number} [get]
+func (h *handlerService) LookupMicrochipHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside LookupMicrochipHandler")
+	lookup, err := h.microchipService.LookupMicrochip(mux.Vars(r)["number"], r.Context())
+	if err != nil {
+		if errors.As(err, &service.MicrochipNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if h.respondMicrochipError(w, err) {
+			return
+		}
+		l.Error().Err(err).Msg("Failed to look up microchip")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, lookup, http.StatusOK)
+}
+
+// respondMicrochipError responds to an invalid or already registered
+// microchip and reports whether err was one.
+func (h *handlerService) respondMicrochipError(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, validators.ErrInvalidMicrochip) || errors.Is(err, service.ErrMicrochipRegisteredInFuture) {
+		h.respond(w, err, http.StatusBadRequest)
+		return true
+	} else if errors.Is(err, service.ErrMicrochipTaken) {
+		h.respond(w, err, http.StatusConflict)
+		return true
+	}
+	return false
+}
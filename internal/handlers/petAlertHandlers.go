@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// ListPetAlertsHandler godoc
+// @Summary List Pet Alerts
+// @Description Lists the allergy, chronic condition and behavior alerts of a pet, most severe first.
+// @Description Requires the pets:read_all permission.
+// @Tags Pet Alert
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Success 200 {array} model.PetAlert "Alerts of the pet"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/alerts [get]
+func (h *handlerService) ListPetAlertsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListPetAlertsHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	alerts, err := h.petAlertService.ListPetAlerts(petID, r.Context())
+	if err != nil {
+		h.respondPetAlertError(w, r, err)
+		return
+	}
+	h.respond(w, alerts, http.StatusOK)
+}
+
+// CreatePetAlertHandler godoc
+// @Summary Add Pet Alert
+// @Description Flags an allergy, chronic condition or behavior warning on a pet.
+// @Description Alerts are shown on every staff response that includes the pet.
+// @Description Requires the pet_alerts:manage permission.
+// @Tags Pet Alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.PetAlertParams true "Alert"
+// @Success 201 {object} model.PetAlert "Alert added"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/alerts [post]
+func (h *handlerService) CreatePetAlertHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreatePetAlertHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PetAlertParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	alert, err := h.petAlertService.AddPetAlert(petID, body, r.Context())
+	if err != nil {
+		h.respondPetAlertError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("alertID", alert.ID).Str("kind", alert.Kind).Str("severity", alert.Severity).Msg("Pet alert added")
+	h.respond(w, alert, http.StatusCreated)
+}
+
+// UpdatePetAlertHandler godoc
+// @Summary Update Pet Alert
+// @Description Changes an alert. Fields that are left out keep their value.
+// @Description Requires the pet_alerts:manage permission.
+// @Tags Pet Alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param alertID path int true "Alert ID"
+// @Param body body service.PetAlertParams true "Alert"
+// @Success 200 {object} model.PetAlert "Alert updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or alert not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/alerts/{alertID} [put]
+func (h *handlerService) UpdatePetAlertHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdatePetAlertHandler")
+	vars := mux.Vars(r)
+	petID, alertID, err := h.petAlertIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PetAlertParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	alert, err := h.petAlertService.UpdatePetAlert(petID, alertID, body, r.Context())
+	if err != nil {
+		h.respondPetAlertError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("alertID", alertID).Msg("Pet alert updated")
+	h.respond(w, alert, http.StatusOK)
+}
+
+// DeletePetAlertHandler godoc
+// @Summary Delete Pet Alert
+// @Description Removes an alert that no longer applies.
+// @Description Requires the pet_alerts:manage permission.
+// @Tags Pet Alert
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param alertID path int true "Alert ID"
+// @Success 204 "Alert deleted"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or alert not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/alerts/{alertID} [delete]
+func (h *handlerService) DeletePetAlertHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeletePetAlertHandler")
+	vars := mux.Vars(r)
+	petID, alertID, err := h.petAlertIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.petAlertService.DeletePetAlert(petID, alertID, r.Context()); err != nil {
+		h.respondPetAlertError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("alertID", alertID).Msg("Pet alert deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+func (h *handlerService) respondPetAlertError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.PetAlertNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrInvalidPetAlertKind) || errors.Is(err, service.ErrInvalidPetAlertSeverity) ||
+		errors.Is(err, service.ErrPetAlertDescriptionRequired) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage pet alert")
+	h.respond(w, err, http.StatusInternalServerError)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -20,10 +21,27 @@ import (
 )
 
 type CreatePetRequest struct {
-	Name           string `json:"name" example:"Buddy"`
-	Species        string `json:"species" example:"Dog"`
-	Breed          string `json:"breed" example:"Golden Retriever"`
-	MedicalHistory string `json:"medical_history" example:"Healthy"`
+	Name                  string     `json:"name" example:"Buddy"`
+	Species               string     `json:"species" example:"Dog"`
+	Breed                 string     `json:"breed" example:"Golden Retriever"`
+	MedicalHistory        string     `json:"medical_history" example:"Healthy"`
+	Microchip             string     `json:"microchip,omitempty" example:"985112345678901"`
+	MicrochipRegisteredAt *time.Time `json:"microchip_registered_at,omitempty"`
+}
+
+// petFromRequest copies the fields of a create or update request into a pet.
+func petFromRequest(petParams CreatePetRequest) model.Pet {
+	pet := model.Pet{
+		Name:                  petParams.Name,
+		Species:               petParams.Species,
+		Breed:                 petParams.Breed,
+		MedicalHistory:        petParams.MedicalHistory,
+		MicrochipRegisteredAt: petParams.MicrochipRegisteredAt,
+	}
+	if petParams.Microchip != "" {
+		pet.Microchip = &petParams.Microchip
+	}
+	return pet
 }
 
 type UploadPetDocumentResponse struct {
@@ -33,7 +51,7 @@ type UploadPetDocumentResponse struct {
 
 // GetPetByIDHandler godoc
 // @Summary Get Pet by ID
-// @Description Fetches a pet by its ID.
+// @Description Fetches a pet by its ID, with the medications it is currently on. Staff also get its alerts.
 // @Tags Pet
 // @Produce json
 // Security BearerAuth
@@ -55,7 +73,7 @@ func (h *handlerService) GetPetByIDHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	l.Debug().Uint("petID", petID).Msg("Fetching pet by ID")
-	pet, err := h.petService.GetPet(petID, r.Context())
+	pet, err := h.petService.GetPetRecord(petID, r.Context())
 	if err != nil {
 		if errors.As(err, &service.PetNotFoundError{}) {
 			h.respond(w, err, http.StatusNotFound)
@@ -75,14 +93,16 @@ func (h *handlerService) GetPetByIDHandler(w http.ResponseWriter, r *http.Reques
 
 // CreatePetHandler godoc
 // @Summary Create a new Pet
-// @Description Creates a new pet with the provided details.
+// @Description Creates a new pet with the provided details. The microchip must be a 15-digit ISO 11784/11785 number; its registration date defaults to now.
+// @Description Species and breed must be catalog entries (see /catalog/species); case and aliases are normalized, and unknown values are rejected with suggestions.
 // @Tags Pet
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param body body CreatePetRequest true "Create pet request body"
 // @Success 201 {object} model.Pet "Pet created successfully"
-// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 400 {object} CatalogErrorResponse "Invalid input, or unknown species or breed with suggestions"
+// @Failure 409 {object} ErrorResponse "Microchip registered to another pet"
 // @Failure 403 {object} ErrorResponse "Resource not owned"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
@@ -97,12 +117,7 @@ func (h *handlerService) CreatePetHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	pet := model.Pet{
-		Name:           petParams.Name,
-		Species:        petParams.Species,
-		Breed:          petParams.Breed,
-		MedicalHistory: petParams.MedicalHistory,
-	}
+	pet := petFromRequest(petParams)
 
 	if pet.Name == "" || pet.Species == "" || pet.Breed == "" {
 		err := errors.New("name, species, and breed are required fields")
@@ -115,6 +130,8 @@ func (h *handlerService) CreatePetHandler(w http.ResponseWriter, r *http.Request
 		if errors.As(err, &validators.ResourceNotOwnedError{}) {
 			h.respond(w, err, http.StatusForbidden)
 			return
+		} else if h.respondMicrochipError(w, err) || h.respondCatalogError(w, err) {
+			return
 		}
 		l.Error().Err(err).Msg("Failed to create pet")
 		h.respond(w, err, http.StatusInternalServerError)
@@ -127,7 +144,8 @@ func (h *handlerService) CreatePetHandler(w http.ResponseWriter, r *http.Request
 
 // UpdatePetHandler godoc
 // @Summary Update Pet
-// @Description Updates an existing pet by its ID.
+// @Description Updates an existing pet by its ID. Household members need manage access.
+// @Description A new species or breed is checked against the catalog like on create.
 // @Tags Pet
 // @Accept json
 // @Produce json
@@ -135,7 +153,8 @@ func (h *handlerService) CreatePetHandler(w http.ResponseWriter, r *http.Request
 // @Param id path int true "Pet ID"
 // @Param body body CreatePetRequest true "Update pet request body"
 // @Success 200 {object} model.Pet "Pet updated successfully"
-// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 400 {object} CatalogErrorResponse "Invalid input, or unknown species or breed with suggestions"
+// @Failure 409 {object} ErrorResponse "Microchip registered to another pet"
 // @Failure 404 {object} ErrorResponse "Pet not found"
 // @Failure 403 {object} ErrorResponse "Resource not owned"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -157,12 +176,7 @@ func (h *handlerService) UpdatePetHandler(w http.ResponseWriter, r *http.Request
 		h.respond(w, err, http.StatusBadRequest)
 		return
 	}
-	pet := model.Pet{
-		Name:           petParams.Name,
-		Species:        petParams.Species,
-		Breed:          petParams.Breed,
-		MedicalHistory: petParams.MedicalHistory,
-	}
+	pet := petFromRequest(petParams)
 
 	l.Debug().Uint("petID", petID).Interface("pet", pet).Msg("Decoded pet data for update")
 
@@ -170,13 +184,11 @@ func (h *handlerService) UpdatePetHandler(w http.ResponseWriter, r *http.Request
 		if errors.As(err, &service.PetNotFoundError{}) {
 			h.respond(w, err, http.StatusNotFound)
 			return
-		}
-		if errors.As(err, &service.UserNotFoundError{}) {
-			h.respond(w, err, http.StatusBadRequest)
-			return
 		} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
 			h.respond(w, err, http.StatusForbidden)
 			return
+		} else if h.respondMicrochipError(w, err) || h.respondCatalogError(w, err) {
+			return
 		}
 		l.Error().Err(err).Msg("Failed to update pet")
 		h.respond(w, err, http.StatusInternalServerError)
@@ -189,11 +201,11 @@ func (h *handlerService) UpdatePetHandler(w http.ResponseWriter, r *http.Request
 
 // DeletePetHandler godoc
 // @Summary Delete Pet
-// @Description Deletes a pet by its ID.
+// @Description Archives a pet by its ID, the same as setting its status to archived. Its records stay readable and its future appointments are cancelled. Household members need manage access.
 // @Tags Pet
 // @Security BearerAuth
 // @Param id path int true "Pet ID"
-// @Success 204 "Pet deleted successfully"
+// @Success 204 "Pet archived successfully"
 // @Failure 404 {object} ErrorResponse "Pet not found"
 // @Failure 403 {object} ErrorResponse "Resource not owned"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -221,18 +233,34 @@ func (h *handlerService) DeletePetHandler(w http.ResponseWriter, r *http.Request
 		h.respond(w, err, http.StatusInternalServerError)
 		return
 	}
-	l.Info().Uint("petID", petID).Msg("Pet deleted successfully")
+	l.Info().Uint("petID", petID).Msg("Pet archived successfully")
 	h.respond(w, nil, http.StatusNoContent)
 }
 
 // GetAllPetsHandler godoc
 // @Summary Get All Pets
-// @Description Fetches all pets.
-// @Description This endpoint is restricted to staff users only.
+// @Description Lists pets page by page, optionally filtered and sorted. Archived pets are left out unless include_archived is set or status asks for them.
+// @Description The response holds one page; the X-Total-Count header has the number of matching pets and X-Next-Cursor, also sent as a Link header, continues the list.
+// @Description Requires the pets:read_all permission.
 // @Tags Pet
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} model.Pet "List of pets"
+// @Param q query string false "Search in the pet's name and its owner's name, email and contact"
+// @Param species query string false "Filter by species"
+// @Param breed query string false "Filter by breed"
+// @Param owner query string false "Filter by owner name"
+// @Param status query string false "Filter by status" Enums(active, deceased, transferred, archived)
+// @Param include_archived query bool false "Also list archived pets"
+// @Param created_from query string false "Only pets created at or after this RFC 3339 time"
+// @Param created_to query string false "Only pets created before this RFC 3339 time"
+// @Param sort query string false "id, name, species, breed, created_at or updated_at, prefixed with - for descending; defaults to id"
+// @Param limit query int false "Page size, defaults to 20 and at most 100"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
+// @Success 200 {array} model.Pet "Page of pets"
+// @Header 200 {integer} X-Total-Count "Number of matching pets"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {string} Link "URL of the next page with rel=next"
+// @Failure 400 {object} ErrorResponse "Invalid input"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Router /staff/pets [get]
@@ -240,22 +268,76 @@ func (h *handlerService) GetAllPetsHandler(w http.ResponseWriter, r *http.Reques
 	l := zerolog.Ctx(r.Context())
 	l.Trace().Msg("Inside GetAllPetsHandler")
 	l.Info().Msg("Incoming request to fetch all pets")
-	pets, err := h.petService.GetAllPets(r.Context())
+	query := r.URL.Query()
+	params := service.PetListParams{
+		Query:     query.Get("q"),
+		Species:   query.Get("species"),
+		Breed:     query.Get("breed"),
+		OwnerName: query.Get("owner"),
+		Status:    query.Get("status"),
+		Sort:      query.Get("sort"),
+		Cursor:    query.Get("cursor"),
+	}
+	var err error
+	if params.IncludeArchived, err = includeArchivedParam(r); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if v := query.Get("created_from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			h.respond(w, errors.New("created_from is not a valid RFC 3339 time"), http.StatusBadRequest)
+			return
+		}
+		params.CreatedFrom = &from
+	}
+	if v := query.Get("created_to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			h.respond(w, errors.New("created_to is not a valid RFC 3339 time"), http.StatusBadRequest)
+			return
+		}
+		params.CreatedTo = &to
+	}
+	if v := query.Get("limit"); v != "" {
+		if params.Limit, err = strconv.Atoi(v); err != nil || params.Limit < 1 {
+			h.respond(w, errors.New("limit is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := h.petService.ListPets(params, r.Context())
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidPetSort) || errors.Is(err, service.ErrInvalidPetCursor) ||
+			errors.Is(err, service.ErrInvalidPetStatus) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
 		l.Error().Err(err).Msg("Failed to fetch all pets")
 		h.respond(w, err, http.StatusInternalServerError)
 		return
 	}
-	h.respond(w, pets, http.StatusOK)
+	w.Header().Set("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		next := *r.URL
+		nextQuery := next.Query()
+		nextQuery.Set("cursor", page.NextCursor)
+		next.RawQuery = nextQuery.Encode()
+		w.Header().Set("Link", "<"+next.RequestURI()+`>; rel="next"`)
+	}
+	h.respond(w, page.Pets, http.StatusOK)
 }
 
 // GetPetsByOwnerHandler godoc
 // @Summary Get Pets by Owner
-// @Description Fetches all pets owned by the authenticated user.
+// @Description Fetches all pets owned by the authenticated user or shared with one of their households. Archived pets are left out unless include_archived is set.
 // @Tags Pet
 // @Produce json
 // @Security BearerAuth
+// @Param include_archived query bool false "Also list archived pets"
 // @Success 200 {array} model.Pet "List of pets owned by the user"
+// @Failure 400 {object} ErrorResponse "Invalid input"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Router /pets [get]
@@ -263,7 +345,12 @@ func (h *handlerService) GetPetsByOwnerHandler(w http.ResponseWriter, r *http.Re
 	l := zerolog.Ctx(r.Context())
 	l.Trace().Msg("Inside GetPetsByOwnerHandler")
 	l.Info().Msg("Incoming request to fetch pets by owner")
-	pets, err := h.petService.GetPetsByOwner(r.Context())
+	includeArchived, err := includeArchivedParam(r)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	pets, err := h.petService.GetPetsByOwner(includeArchived, r.Context())
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to fetch pets by owner")
 		h.respond(w, err, http.StatusInternalServerError)
@@ -275,7 +362,7 @@ func (h *handlerService) GetPetsByOwnerHandler(w http.ResponseWriter, r *http.Re
 // UploadPetDocumentHandler godoc
 // @Summary Upload Pet Document
 // @Description Uploads a document for a specific pet.
-// @Description This endpoint is restricted to staff users only.
+// @Description Requires the documents:upload permission.
 // @Tags Pet
 // @Accept multipart/form-data
 // @Produce json
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/imaging"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+const maxPetPhotoBytes = 10 << 20 // 10 MB
+
+// UploadPetPhotoHandler godoc
+// @Summary Upload Pet Photo
+// @Description Sets the profile photo of a pet, replacing any previous one. JPEG, PNG and GIF images are accepted.
+// @Description The photo is stored in large (1600 px), medium (512 px) and small (128 px) JPEG versions without EXIF metadata.
+// @Description The pet's owner, household members with manage access and staff with the pets:write_all permission may upload it.
+// @Tags Pet Photo
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param photo formData file true "Image file"
+// @Success 200 {object} model.Pet "Photo uploaded"
+// @Failure 400 {object} ErrorResponse "Invalid or unsupported image"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/photo [post]
+func (h *handlerService) UploadPetPhotoHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UploadPetPhotoHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPetPhotoBytes)
+	if err := r.ParseMultipartForm(maxPetPhotoBytes); err != nil {
+		l.Error().Err(err).Msg("Failed to parse multipart form")
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	file, _, err := r.FormFile("photo")
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+
+	pet, err := h.petService.SetPetPhoto(petID, data, r.Context())
+	if err != nil {
+		h.respondPetPhotoError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Msg("Pet photo uploaded")
+	h.respond(w, pet, http.StatusOK)
+}
+
+// GetPetPhotoHandler godoc
+// @Summary Get Pet Photo
+// @Description Fetches one size of a pet's profile photo. The URLs are listed in the pet's photo_urls.
+// @Tags Pet Photo
+// @Produce jpeg
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param size path string true "Photo size" Enums(large, medium, small)
+// @Success 200 {string} binary "Photo"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet or photo not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/photo/{size} [get]
+func (h *handlerService) GetPetPhotoHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetPetPhotoHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	path, err := h.petService.GetPetPhotoPath(petID, vars["size"], r.Context())
+	if err != nil {
+		h.respondPetPhotoError(w, r, err)
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			h.respond(w, service.ErrNoPetPhoto, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to open pet photo")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	// Photo URLs change with every upload, so browsers may keep them.
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Header().Set("Content-Type", "image/jpeg")
+	http.ServeFile(w, r, path)
+}
+
+// DeletePetPhotoHandler godoc
+// @Summary Delete Pet Photo
+// @Description Removes the profile photo of a pet.
+// @Tags Pet Photo
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Success 204 "Photo deleted"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet or photo not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/photo [delete]
+func (h *handlerService) DeletePetPhotoHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeletePetPhotoHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.petService.DeletePetPhoto(petID, r.Context()); err != nil {
+		h.respondPetPhotoError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Msg("Pet photo deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+func (h *handlerService) respondPetPhotoError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.Is(err, service.ErrNoPetPhoto) ||
+		errors.Is(err, service.ErrUnknownPhotoSize) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrImageTooLarge) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage pet photo")
+	h.respond(w, err, http.StatusInternalServerError)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// SetPetStatusHandler godoc
+// @Summary Set Pet Status
+// @Description Moves a pet to active, deceased, transferred (left the clinic's care) or archived. Household members need manage access.
+// @Description A pet that is not active cannot be booked; its future appointments and pending transfers are cancelled and its records stay readable.
+// @Description deceased_on defaults to today. Only staff with the pets:write_all permission can make a deceased pet active again.
+// @Tags Pet
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.PetStatusParams true "New status"
+// @Success 200 {object} service.PetStatusChange "Status changed"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned or pet deceased"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/status [put]
+func (h *handlerService) SetPetStatusHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SetPetStatusHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PetStatusParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	change, err := h.petService.SetPetStatus(petID, body, r.Context())
+	if err != nil {
+		if errors.As(err, &service.PetNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.As(err, &validators.ResourceNotOwnedError{}) || errors.Is(err, service.ErrPetDeceased) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		} else if errors.Is(err, service.ErrInvalidPetStatus) || errors.Is(err, service.ErrDeceasedOnInFuture) ||
+			errors.Is(err, service.ErrDeceasedOnNotAllowed) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to set pet status")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, change, http.StatusOK)
+}
+
+// includeArchivedParam reads the include_archived query parameter of the pet
+// lists.
+func includeArchivedParam(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("include_archived")
+	if v == "" {
+		return false, nil
+	}
+	includeArchived, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.New("include_archived is not valid")
+	}
+	return includeArchived, nil
+}
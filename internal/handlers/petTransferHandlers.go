@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// StartPetTransferHandler godoc
+// @Summary Start Pet Transfer
+// @Description Offers a pet to whoever has the account with the given email, e.g. after a rehoming or an adoption. The recipient is notified by email.
+// @Description The response is the same whether or not an account uses the email; to_user_id is filled in once the recipient answers.
+// @Description The pet changes owner once the recipient accepts; its appointments, documents and medical record move with it.
+// @Description The pet's owner may start a transfer, as may staff with the pets:write_all permission.
+// @Tags Pet Transfer
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.PetTransferParams true "Transfer"
+// @Success 201 {object} model.PetTransfer "Transfer started"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 409 {object} ErrorResponse "Transfer already pending or pet not active"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/transfers [post]
+func (h *handlerService) StartPetTransferHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside StartPetTransferHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PetTransferParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	transfer, err := h.petTransferService.StartPetTransfer(petID, body, r.Context())
+	if err != nil {
+		h.respondPetTransferError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("transferID", transfer.ID).Msg("Pet transfer started")
+	h.respond(w, transfer, http.StatusCreated)
+}
+
+// ListPetTransfersHandler godoc
+// @Summary List Pet Transfers
+// @Description Lists the ownership history of a pet, newest first.
+// @Tags Pet Transfer
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Success 200 {array} model.PetTransfer "Transfers of the pet"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/transfers [get]
+func (h *handlerService) ListPetTransfersHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListPetTransfersHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	transfers, err := h.petTransferService.ListPetTransfers(petID, r.Context())
+	if err != nil {
+		h.respondPetTransferError(w, r, err)
+		return
+	}
+	h.respond(w, transfers, http.StatusOK)
+}
+
+// ListMyPetTransfersHandler godoc
+// @Summary List My Pet Transfers
+// @Description Lists the transfers the authenticated user gives away or receives, newest first. Unanswered transfers sent to the user's email only show once it is verified.
+// @Tags Pet Transfer
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.PetTransfer "Transfers"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /transfers [get]
+func (h *handlerService) ListMyPetTransfersHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListMyPetTransfersHandler")
+	transfers, err := h.petTransferService.ListMyPetTransfers(r.Context())
+	if err != nil {
+		h.respondPetTransferError(w, r, err)
+		return
+	}
+	h.respond(w, transfers, http.StatusOK)
+}
+
+// AcceptPetTransferHandler godoc
+// @Summary Accept Pet Transfer
+// @Description Accepts a pet offered to the authenticated user, who becomes its owner. The user's email must be verified.
+// @Tags Pet Transfer
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} model.PetTransfer "Transfer accepted"
+// @Failure 400 {object} ErrorResponse "Invalid transfer ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not the recipient or email not verified"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 409 {object} ErrorResponse "Transfer no longer pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /transfers/{id}/accept [post]
+func (h *handlerService) AcceptPetTransferHandler(w http.ResponseWriter, r *http.Request) {
+	h.answerPetTransfer(w, r, h.petTransferService.AcceptPetTransfer)
+}
+
+// DeclinePetTransferHandler godoc
+// @Summary Decline Pet Transfer
+// @Description Declines a pet offered to the authenticated user. The pet stays with its owner. The user's email must be verified.
+// @Tags Pet Transfer
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} model.PetTransfer "Transfer declined"
+// @Failure 400 {object} ErrorResponse "Invalid transfer ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not the recipient or email not verified"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 409 {object} ErrorResponse "Transfer no longer pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /transfers/{id}/decline [post]
+func (h *handlerService) DeclinePetTransferHandler(w http.ResponseWriter, r *http.Request) {
+	h.answerPetTransfer(w, r, h.petTransferService.DeclinePetTransfer)
+}
+
+// CancelPetTransferHandler godoc
+// @Summary Cancel Pet Transfer
+// @Description Withdraws a pending transfer. The pet's owner, whoever started the transfer and staff with the pets:write_all permission may cancel it.
+// @Tags Pet Transfer
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} model.PetTransfer "Transfer cancelled"
+// @Failure 400 {object} ErrorResponse "Invalid transfer ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Transfer not found"
+// @Failure 409 {object} ErrorResponse "Transfer no longer pending"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /transfers/{id}/cancel [post]
+func (h *handlerService) CancelPetTransferHandler(w http.ResponseWriter, r *http.Request) {
+	h.answerPetTransfer(w, r, h.petTransferService.CancelPetTransfer)
+}
+
+func (h *handlerService) answerPetTransfer(w http.ResponseWriter, r *http.Request, answer func(uint, context.Context) (model.PetTransfer, error)) {
+	l := zerolog.Ctx(r.Context())
+	vars := mux.Vars(r)
+	transferID, err := h.petTransferIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	transfer, err := answer(transferID, r.Context())
+	if err != nil {
+		h.respondPetTransferError(w, r, err)
+		return
+	}
+	l.Info().Uint("transferID", transferID).Str("status", transfer.Status).Msg("Pet transfer updated")
+	h.respond(w, transfer, http.StatusOK)
+}
+
+func (h *handlerService) respondPetTransferError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.PetTransferNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) || errors.Is(err, service.ErrNotTransferRecipient) ||
+		errors.Is(err, service.ErrEmailNotVerified) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrTransferPending) || errors.Is(err, service.ErrTransferNotPending) ||
+		errors.As(err, &service.PetNotActiveError{}) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrTransferToSelf) || errors.Is(err, validators.ErrInvalidEmail) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage pet transfer")
+	h.respond(w, err, http.StatusInternalServerError)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// ListPrescriptionsHandler godoc
+// @Summary List Prescriptions
+// @Description Lists every prescription of a pet, newest first, including finished and discontinued ones.
+// @Tags Prescription
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Success 200 {array} model.Prescription "Prescriptions of the pet"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/prescriptions [get]
+func (h *handlerService) ListPrescriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListPrescriptionsHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	prescriptions, err := h.prescriptionService.ListPrescriptions(petID, r.Context())
+	if err != nil {
+		h.respondPrescriptionError(w, r, err)
+		return
+	}
+	h.respond(w, prescriptions, http.StatusOK)
+}
+
+// CreatePrescriptionHandler godoc
+// @Summary Prescribe Medication
+// @Description Prescribes a medication to a pet, signed by the current user.
+// @Description The response warns when the pet is already on the same drug, has a matching allergy alert or its medical history mentions the drug; the prescription is created either way.
+// @Description Requires the prescriptions:manage permission.
+// @Tags Prescription
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.PrescriptionParams true "Prescription"
+// @Success 201 {object} service.CreatedPrescription "Prescription created"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or appointment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/prescriptions [post]
+func (h *handlerService) CreatePrescriptionHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreatePrescriptionHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.PrescriptionParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	created, err := h.prescriptionService.Prescribe(petID, body, r.Context())
+	if err != nil {
+		h.respondPrescriptionError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("prescriptionID", created.Prescription.ID).Str("drug", created.Prescription.Drug).Msg("Prescription created")
+	h.respond(w, created, http.StatusCreated)
+}
+
+// RefillPrescriptionHandler godoc
+// @Summary Refill Prescription
+// @Description Uses up one refill and extends the course by its duration.
+// @Description Requires the prescriptions:manage permission.
+// @Tags Prescription
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param prescriptionID path int true "Prescription ID"
+// @Success 200 {object} model.Prescription "Prescription refilled"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or prescription not found"
+// @Failure 409 {object} ErrorResponse "No refills remaining or discontinued"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/prescriptions/{prescriptionID}/refill [post]
+func (h *handlerService) RefillPrescriptionHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RefillPrescriptionHandler")
+	vars := mux.Vars(r)
+	petID, prescriptionID, err := h.prescriptionIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	prescription, err := h.prescriptionService.RefillPrescription(petID, prescriptionID, r.Context())
+	if err != nil {
+		h.respondPrescriptionError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("prescriptionID", prescriptionID).Int("refillsRemaining", prescription.RefillsRemaining).Msg("Prescription refilled")
+	h.respond(w, prescription, http.StatusOK)
+}
+
+// DiscontinuePrescriptionHandler godoc
+// @Summary Discontinue Prescription
+// @Description Stops a medication before its course ends. It no longer shows as active and cannot be refilled.
+// @Description Requires the prescriptions:manage permission.
+// @Tags Prescription
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param prescriptionID path int true "Prescription ID"
+// @Success 200 {object} model.Prescription "Prescription discontinued"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or prescription not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/prescriptions/{prescriptionID}/discontinue [post]
+func (h *handlerService) DiscontinuePrescriptionHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DiscontinuePrescriptionHandler")
+	vars := mux.Vars(r)
+	petID, prescriptionID, err := h.prescriptionIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	prescription, err := h.prescriptionService.DiscontinuePrescription(petID, prescriptionID, r.Context())
+	if err != nil {
+		h.respondPrescriptionError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("prescriptionID", prescriptionID).Msg("Prescription discontinued")
+	h.respond(w, prescription, http.StatusOK)
+}
+
+func (h *handlerService) respondPrescriptionError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.PrescriptionNotFoundError{}) ||
+		errors.As(err, &service.AppointmentNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrNoRefillsRemaining) || errors.Is(err, service.ErrPrescriptionInactive) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrPrescriptionIncomplete) || errors.Is(err, service.ErrInvalidDuration) ||
+		errors.Is(err, service.ErrInvalidRefills) || errors.Is(err, service.ErrAppointmentOfOtherPet) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage prescription")
+	h.respond(w, err, http.StatusInternalServerError)
+}
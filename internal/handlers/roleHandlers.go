@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// ListPermissionsHandler godoc
+// @Summary List Permissions
+// @Description Lists every permission that can be granted to a role.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.PermissionInfo "Permissions"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Router /admin/permissions [get]
+func (h *handlerService) ListPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListPermissionsHandler")
+	h.respond(w, h.roleService.ListPermissions(r.Context()), http.StatusOK)
+}
+
+// ListRolesHandler godoc
+// @Summary List Roles
+// @Description Lists every role together with the permissions it grants.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.Role "Roles"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/roles [get]
+func (h *handlerService) ListRolesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListRolesHandler")
+	roles, err := h.roleService.ListRoles(r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to list roles")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, roles, http.StatusOK)
+}
+
+// GetRoleHandler godoc
+// @Summary Get Role
+// @Description Fetches a role and the permissions it grants.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} model.Role "Role"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Role not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/roles/{name} [get]
+func (h *handlerService) GetRoleHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetRoleHandler")
+	role, err := h.roleService.GetRole(mux.Vars(r)["name"], r.Context())
+	if err != nil {
+		h.respondRoleError(w, r, err)
+		return
+	}
+	h.respond(w, role, http.StatusOK)
+}
+
+// CreateRoleHandler godoc
+// @Summary Create Role
+// @Description Creates a custom role, e.g. receptionist, with the given permissions. Only permissions the caller holds can be granted.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.RoleParams true "Role"
+// @Success 201 {object} model.Role "Role created"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} ErrorResponse "Role already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/roles [post]
+func (h *handlerService) CreateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreateRoleHandler")
+	var body service.RoleParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	role, err := h.roleService.CreateRole(body, r.Context())
+	if err != nil {
+		h.respondRoleError(w, r, err)
+		return
+	}
+	l.Info().Str("role", role.Name).Strs("permissions", role.Permissions).Msg("Role created")
+	h.respond(w, role, http.StatusCreated)
+}
+
+// UpdateRoleHandler godoc
+// @Summary Update Role
+// @Description Replaces the description and permissions of a role. The admin role and the caller's own role cannot be edited.
+// @Description The caller must hold every permission the role has now and every permission it is given.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Param body body service.RoleParams true "Role; the name field is ignored"
+// @Success 200 {object} model.Role "Role updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Role not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/roles/{name} [put]
+func (h *handlerService) UpdateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateRoleHandler")
+	name := mux.Vars(r)["name"]
+	var body service.RoleParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	role, err := h.roleService.UpdateRole(name, body, r.Context())
+	if err != nil {
+		h.respondRoleError(w, r, err)
+		return
+	}
+	l.Info().Str("role", role.Name).Strs("permissions", role.Permissions).Msg("Role updated")
+	h.respond(w, role, http.StatusOK)
+}
+
+// DeleteRoleHandler godoc
+// @Summary Delete Role
+// @Description Deletes a custom role. Built-in roles and roles still held by a user cannot be deleted.
+// @Description Requires the roles:manage permission.
+// @Tags Admin
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 204 "Role deleted"
+// @Failure 400 {object} ErrorResponse "Built-in role"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Role not found"
+// @Failure 409 {object} ErrorResponse "Role still assigned to users"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/roles/{name} [delete]
+func (h *handlerService) DeleteRoleHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeleteRoleHandler")
+	name := mux.Vars(r)["name"]
+	if err := h.roleService.DeleteRole(name, r.Context()); err != nil {
+		h.respondRoleError(w, r, err)
+		return
+	}
+	l.Info().Str("role", name).Msg("Role deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+func (h *handlerService) respondRoleError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.RoleNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.Is(err, service.ErrRoleExists) || errors.Is(err, service.ErrRoleInUse) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrBuiltInRole) || errors.Is(err, service.ErrInvalidRoleName) || errors.As(err, &service.InvalidPermissionError{}) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	} else if errors.Is(err, service.ErrPermissionNotHeld) || errors.Is(err, service.ErrCannotEditOwnRole) || errors.Is(err, service.ErrRoleNotGrantable) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage role")
+	h.respond(w, err, http.StatusInternalServerError)
+}
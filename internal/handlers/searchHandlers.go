@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// SearchHandler godoc
+// @Summary Search
+// @Description Searches owners by name, email and phone, pets by name, breed and medical history, and visit notes, from a single search box.
+// @Description Every word is matched as a prefix and records matching more words rank higher, so "Buddy 98765" finds both the pet and its owner's phone number.
+// @Description Results are grouped by entity type, best match first. Visit notes are only searched with the visit_notes:read permission.
+// @Description Requires the pets:read_all permission.
+// @Tags Search
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text"
+// @Param limit query int false "Results per entity type, defaults to 10 and at most 50"
+// @Success 200 {object} service.SearchResults "Grouped results"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/search [get]
+func (h *handlerService) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SearchHandler")
+	query := r.URL.Query()
+	params := service.SearchParams{Query: query.Get("q")}
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if params.Limit, err = strconv.Atoi(v); err != nil || params.Limit < 1 {
+			h.respond(w, errors.New("limit is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	results, err := h.searchService.Search(params, r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrEmptySearch) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to search")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, results, http.StatusOK)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"3q2-7wVn0bXk1Yf5cK8sZ0pT4mR6uW9aD2gH5jL8nQ"`
+}
+
+type LogoutRequest struct {
+	AllDevices bool `json:"all_devices" example:"false"`
+}
+
+// RefreshTokenHandler godoc
+// @Summary Refresh Access Token
+// @Description Exchanges a refresh token for a new access token and a new refresh token.
+// @Description The presented refresh token is rotated and cannot be used again.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body RefreshTokenRequest true "Refresh token request body"
+// @Success 200 {object} LoginSuccessResponse "Tokens refreshed"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Invalid or expired refresh token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /token/refresh [post]
+func (h *handlerService) RefreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RefreshTokenHandler")
+	var body RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	tokens, err := h.sessionService.RefreshSession(body.RefreshToken, r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			h.respond(w, err, http.StatusUnauthorized)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to refresh token")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Msg("Token refreshed successfully")
+	h.respond(w, tokens, http.StatusOK)
+}
+
+// LogoutHandler godoc
+// @Summary User Logout
+// @Description Revokes the current session. Set all_devices to revoke every session of the user.
+// @Tags User
+// @Accept json
+// @Security BearerAuth
+// @Param body body LogoutRequest false "Logout request body"
+// @Success 204 "Logged out successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /logout [post]
+func (h *handlerService) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside LogoutHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	sessionID := r.Context().Value(middleware.ContextKeySessionID).(uint)
+	var body LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if body.AllDevices {
+		err = h.sessionService.RevokeAllSessions(userID, r.Context())
+	} else {
+		err = h.sessionService.RevokeSession(sessionID, userID, r.Context())
+	}
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to logout user")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", userID).Bool("allDevices", body.AllDevices).Msg("User logged out successfully")
+	h.respond(w, nil, http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// SSOLoginHandler godoc
+// @Summary Start Single Sign-On
+// @Description Redirects to the clinic's OpenID Connect identity provider to log in staff.
+// @Description Sets a short-lived cookie that ties the login to this browser; the callback is rejected without it.
+// @Description Pet owners keep logging in through /login.
+// @Tags User
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} ErrorResponse "Single sign-on is not configured"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /sso/oidc/login [get]
+func (h *handlerService) SSOLoginHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SSOLoginHandler")
+	login, err := h.userService.BeginSSOLogin(r.Context())
+	if err != nil {
+		if errors.Is(err, oidc.ErrNotConfigured) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to start single sign-on")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, ssoCookie(login.Binding, login.ExpiresAt))
+	http.Redirect(w, r, login.AuthURL, http.StatusFound)
+}
+
+// SSOCallbackHandler godoc
+// @Summary Complete Single Sign-On
+// @Description Handles the identity provider's redirect after login. The user's role is taken from
+// @Description their IdP groups, and a local account is created on first login.
+// @Tags User
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State from the login redirect"
+// @Success 200 {object} LoginSuccessResponse "Login successful"
+// @Failure 400 {object} ErrorResponse "Invalid or expired state, or the login was started in another browser"
+// @Failure 401 {object} ErrorResponse "Identity provider rejected the login"
+// @Failure 403 {object} ErrorResponse "No clinic role or account disabled"
+// @Failure 404 {object} ErrorResponse "Single sign-on is not configured"
+// @Failure 409 {object} ErrorResponse "Conflicting local account"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /sso/oidc/callback [get]
+func (h *handlerService) SSOCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SSOCallbackHandler")
+	query := r.URL.Query()
+	if idpErr := query.Get("error"); idpErr != "" {
+		l.Info().Str("error", idpErr).Str("description", query.Get("error_description")).Msg("Identity provider returned an error")
+		h.respond(w, errors.New("single sign-on failed: "+idpErr), http.StatusUnauthorized)
+		return
+	}
+	binding := ""
+	if cookie, err := r.Cookie(ssoCookieName); err == nil {
+		binding = cookie.Value
+	}
+	http.SetCookie(w, ssoCookie("", time.Unix(0, 0)))
+	tokens, err := h.userService.CompleteSSOLogin(query.Get("code"), query.Get("state"), binding, r.Context())
+	if err != nil {
+		if errors.Is(err, oidc.ErrNotConfigured) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrInvalidSSOState) || errors.Is(err, service.ErrSSOMissingEmail) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.Is(err, service.ErrSSONoRole) || errors.Is(err, service.ErrAccountDisabled) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		} else if errors.Is(err, service.ErrSSOAccountConflict) {
+			h.respond(w, err, http.StatusConflict)
+			return
+		} else if errors.Is(err, service.ErrSSOLoginFailed) {
+			l.Warn().Err(err).Msg("Identity provider login rejected")
+			h.respond(w, service.ErrSSOLoginFailed, http.StatusUnauthorized)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to complete single sign-on")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Msg("Single sign-on successful")
+	h.respond(w, tokens, http.StatusOK)
+}
+
+// ssoCookieName holds the binding of a single sign-on attempt to the browser
+// that started it. It is only sent back to the callback.
+const ssoCookieName = "pcms_sso"
+
+// ssoCookie builds the binding cookie. SameSite=Lax still sends it on the
+// top-level redirect back from the identity provider.
+func ssoCookie(value string, expires time.Time) *http.Cookie {
+	secure := false
+	if provider, err := oidc.Get(); err == nil {
+		secure = strings.HasPrefix(provider.Config.RedirectURL, "https://")
+	}
+	return &http.Cookie{
+		Name:     ssoCookieName,
+		Value:    value,
+		Path:     "/sso/oidc",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
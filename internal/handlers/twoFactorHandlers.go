@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired  bool   `json:"two_factor_required" example:"true"`
+	EnrollmentRequired bool   `json:"enrollment_required" example:"false"`
+	ChallengeToken     string `json:"challenge_token" example:"Yk3mN8qR2vX5zB7cF1hJ4lP6sT9wA0dG3kM5nQ8rU2x"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" example:"Yk3mN8qR2vX5zB7cF1hJ4lP6sT9wA0dG3kM5nQ8rU2x"`
+	Code           string `json:"code" example:"123456"`
+	RecoveryCode   string `json:"recovery_code" example:"abcde-fghij"`
+}
+
+type TwoFactorEnrollRequest struct {
+	ChallengeToken string `json:"challenge_token" example:"Yk3mN8qR2vX5zB7cF1hJ4lP6sT9wA0dG3kM5nQ8rU2x"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" example:"s3cure-Passw0rd"`
+	Code     string `json:"code" example:"123456"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"abcde-fghij,klmno-pqrst"`
+}
+
+// respondTwoFactorError maps the errors shared by the two-factor endpoints.
+func (h *handlerService) respondTwoFactorError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.Is(err, service.ErrInvalidTwoFactorCode) || errors.Is(err, service.ErrInvalidCredentials) {
+		h.respond(w, err, http.StatusUnauthorized)
+		return
+	} else if errors.Is(err, service.ErrInvalidUserToken) {
+		h.respond(w, err, http.StatusUnauthorized)
+		return
+	} else if errors.Is(err, service.ErrTwoFactorAlreadyEnabled) || errors.Is(err, service.ErrTwoFactorNotEnabled) ||
+		errors.Is(err, service.ErrTwoFactorEnrollmentNotStarted) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrTwoFactorMandatory) || errors.Is(err, service.ErrAccountDisabled) ||
+		errors.Is(err, service.ErrRoleNotGrantable) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.As(err, &service.UserNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	}
+	l.Error().Err(err).Msg("Two-factor request failed")
+	h.respond(w, err, http.StatusInternalServerError)
+}
+
+// TwoFactorLoginHandler godoc
+// @Summary Complete Two-Factor Login
+// @Description Completes a login that returned a two-factor challenge, using either a TOTP code or a recovery code.
+// @Description If the challenge required enrollment, the code confirms the new authenticator and recovery codes are returned with the tokens.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body TwoFactorLoginRequest true "Two-factor login request body"
+// @Success 200 {object} service.TwoFactorLoginResult "Login successful"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Invalid code or challenge"
+// @Failure 403 {object} ErrorResponse "Account disabled"
+// @Failure 409 {object} ErrorResponse "Enrollment has not been started"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /login/2fa [post]
+func (h *handlerService) TwoFactorLoginHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside TwoFactorLoginHandler")
+	var body TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	result, err := h.userService.CompleteTwoFactorLogin(body.ChallengeToken, body.Code, body.RecoveryCode, r.Context())
+	if err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	l.Info().Msg("Two-factor login successful")
+	h.respond(w, result, http.StatusOK)
+}
+
+// TwoFactorLoginEnrollHandler godoc
+// @Summary Enroll Two-Factor During Login
+// @Description Starts TOTP enrollment for a user whose role requires two-factor authentication but who has not set it up yet.
+// @Description The enrollment is confirmed by completing the login through /login/2fa.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body TwoFactorEnrollRequest true "Enrollment request body"
+// @Success 200 {object} service.TwoFactorEnrollment "TOTP secret and provisioning URI"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Invalid challenge"
+// @Failure 409 {object} ErrorResponse "Two-factor already enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /login/2fa/enroll [post]
+func (h *handlerService) TwoFactorLoginEnrollHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside TwoFactorLoginEnrollHandler")
+	var body TwoFactorEnrollRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	enrollment, err := h.userService.BeginChallengeEnrollment(body.ChallengeToken, r.Context())
+	if err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	h.respond(w, enrollment, http.StatusOK)
+}
+
+// EnrollTwoFactorHandler godoc
+// @Summary Start Two-Factor Enrollment
+// @Description Generates a new TOTP secret for the authenticated user. It takes effect once confirmed.
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.TwoFactorEnrollment "TOTP secret and provisioning URI"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Two-factor already enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /owners/2fa/enroll [post]
+func (h *handlerService) EnrollTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside EnrollTwoFactorHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	enrollment, err := h.userService.BeginTwoFactorEnrollment(userID, r.Context())
+	if err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	h.respond(w, enrollment, http.StatusOK)
+}
+
+// ConfirmTwoFactorHandler godoc
+// @Summary Confirm Two-Factor Enrollment
+// @Description Enables two-factor authentication with a code from the authenticator and returns one-time recovery codes.
+// @Description The recovery codes are only shown once.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} RecoveryCodesResponse "Two-factor enabled"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid code"
+// @Failure 409 {object} ErrorResponse "Enrollment not started or already enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /owners/2fa/confirm [post]
+func (h *handlerService) ConfirmTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ConfirmTwoFactorHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	var body TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	codes, err := h.userService.ConfirmTwoFactorEnrollment(userID, body.Code, r.Context())
+	if err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	l.Info().Uint("userID", userID).Msg("Two-factor authentication enabled")
+	h.respond(w, RecoveryCodesResponse{RecoveryCodes: codes}, http.StatusOK)
+}
+
+// DisableTwoFactorHandler godoc
+// @Summary Disable Two-Factor
+// @Description Disables two-factor authentication for the authenticated user. The password and a current code are required.
+// @Description Users whose role requires two-factor authentication cannot disable it.
+// @Tags User
+// @Accept json
+// @Security BearerAuth
+// @Param body body DisableTwoFactorRequest true "Password and TOTP code"
+// @Success 204 "Two-factor disabled"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized, wrong password or invalid code"
+// @Failure 403 {object} ErrorResponse "Two-factor is mandatory for this role"
+// @Failure 409 {object} ErrorResponse "Two-factor not enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /owners/2fa/disable [post]
+func (h *handlerService) DisableTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DisableTwoFactorHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	var body DisableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.DisableTwoFactor(userID, body.Password, body.Code, r.Context()); err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	l.Info().Uint("userID", userID).Msg("Two-factor authentication disabled")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodesHandler godoc
+// @Summary Regenerate Recovery Codes
+// @Description Replaces all recovery codes of the authenticated user. A current TOTP code is required.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} RecoveryCodesResponse "New recovery codes"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized or invalid code"
+// @Failure 409 {object} ErrorResponse "Two-factor not enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /owners/2fa/recovery-codes [post]
+func (h *handlerService) RegenerateRecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RegenerateRecoveryCodesHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	var body TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	codes, err := h.userService.RegenerateRecoveryCodes(userID, body.Code, r.Context())
+	if err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	h.respond(w, RecoveryCodesResponse{RecoveryCodes: codes}, http.StatusOK)
+}
+
+// ResetTwoFactorHandler godoc
+// @Summary Reset Two-Factor
+// @Description Clears the two-factor setup of a user who lost access to their authenticator, and revokes their sessions.
+// @Description Requires the users:manage permission, and every permission of the user's role unless the caller is an admin.
+// @Tags Admin
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Success 204 "Two-factor reset"
+// @Failure 400 {object} ErrorResponse "Invalid user ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/2fa/reset [post]
+func (h *handlerService) ResetTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ResetTwoFactorHandler")
+	vars := mux.Vars(r)
+	userID, err := h.userIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.ResetTwoFactor(userID, r.Context()); err != nil {
+		h.respondTwoFactorError(w, r, err)
+		return
+	}
+	l.Info().Uint("userID", userID).Msg("Two-factor authentication reset by admin")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// GetTwoFactorSettingsHandler godoc
+// @Summary Get Two-Factor Settings
+// @Description Returns the clinic-wide two-factor settings.
+// @Description Requires the settings:manage permission.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.TwoFactorSettings "Two-factor settings"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/settings/2fa [get]
+func (h *handlerService) GetTwoFactorSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetTwoFactorSettingsHandler")
+	settings, err := h.settingService.GetTwoFactorSettings(r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to fetch two-factor settings")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, settings, http.StatusOK)
+}
+
+// UpdateTwoFactorSettingsHandler godoc
+// @Summary Update Two-Factor Settings
+// @Description Updates the clinic-wide two-factor settings. When required_for_staff is set,
+// @Description users of every role except owner must enroll at their next login if they have not yet.
+// @Description Requires the settings:manage permission.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body service.TwoFactorSettings true "Two-factor settings"
+// @Success 200 {object} service.TwoFactorSettings "Updated settings"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/settings/2fa [put]
+func (h *handlerService) UpdateTwoFactorSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateTwoFactorSettingsHandler")
+	var body service.TwoFactorSettings
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.settingService.UpdateTwoFactorSettings(body, r.Context()); err != nil {
+		l.Error().Err(err).Msg("Failed to update two-factor settings")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Bool("requiredForStaff", body.RequiredForStaff).Msg("Two-factor settings updated")
+	h.respond(w, body, http.StatusOK)
+}
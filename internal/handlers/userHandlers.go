@@ -3,14 +3,16 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/lockout"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
 	"github.com/rs/zerolog"
-	"gorm.io/gorm"
 
 	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
 )
@@ -20,7 +22,9 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 type LoginSuccessResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ..."`
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ..."`
+	RefreshToken string `json:"refresh_token" example:"3q2-7wVn0bXk1Yf5cK8sZ0pT4mR6uW9aD2gH5jL8nQ"`
+	ExpiresIn    int64  `json:"expires_in" example:"300"`
 }
 
 type UpdateUserRequest struct {
@@ -34,16 +38,26 @@ type ErrorResponse struct {
 	Error string `json:"error" example:"error message"`
 }
 
+type MessageResponse struct {
+	Message string `json:"message" example:"Request processed successfully"`
+}
+
 // LoginHandler godoc
 // @Summary User Login
 // @Description Logs in a user with username and password.
+// @Description When two-factor authentication applies, a challenge is returned instead of tokens
+// @Description and the login is completed through /login/2fa.
+// @Description Repeated failures for a username or client IP lead to a temporary lockout that grows with each further failure.
 // @Tags User
 // @Accept json
 // @Produce json
 // @Param body body LoginRequest true "Login request body"
 // @Success 200 {object} LoginSuccessResponse "Login successful"
+// @Success 202 {object} TwoFactorChallengeResponse "Second factor required"
 // @Failure 400 {object} ErrorResponse "Invalid input"
-// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 401 {object} ErrorResponse "Invalid username or password"
+// @Failure 403 {object} ErrorResponse "Account disabled, email not verified or signs in through single sign-on"
+// @Failure 429 {object} ErrorResponse "Too many failed attempts, see the Retry-After header"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /login [post]
 func (h *handlerService) LoginHandler(w http.ResponseWriter, r *http.Request) {
@@ -55,23 +69,39 @@ func (h *handlerService) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	l.Debug().Str("username", body.Username).Msg("User login attempt")
-	token, err := h.userService.Login(body.Username, body.Password)
+	tokens, err := h.userService.Login(body.Username, body.Password, r.Context())
 	if err != nil {
+		var lockedErr lockout.LockedError
 		if errors.Is(err, service.ErrInvalidCredentials) {
-			h.respond(w, err, http.StatusBadRequest)
+			h.respond(w, err, http.StatusUnauthorized)
 			return
 		} else if errors.Is(err, service.ErrInvalidUserInput) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
-		} else if errors.Is(err, gorm.ErrRecordNotFound) {
-			h.respond(w, service.ErrInvalidCredentials, http.StatusNotFound)
+		} else if errors.As(err, &lockedErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+			h.respond(w, lockedErr, http.StatusTooManyRequests)
+			return
+		} else if errors.Is(err, service.ErrAccountDisabled) || errors.Is(err, service.ErrEmailNotVerified) || errors.Is(err, service.ErrSSORequired) {
+			h.respond(w, err, http.StatusForbidden)
+			return
+		}
+		var twoFactorErr service.TwoFactorRequiredError
+		if errors.As(err, &twoFactorErr) {
+			l.Info().Str("username", body.Username).Msg("Second factor required")
+			h.respond(w, TwoFactorChallengeResponse{
+				TwoFactorRequired:  true,
+				EnrollmentRequired: twoFactorErr.EnrollmentRequired,
+				ChallengeToken:     twoFactorErr.ChallengeToken,
+			}, http.StatusAccepted)
 			return
 		}
 		l.Error().Err(err).Msg("Failed to login user")
 		h.respond(w, err, http.StatusInternalServerError)
+		return
 	}
 	l.Info().Str("username", body.Username).Msg("User login successful")
-	h.respond(w, map[string]string{"token": token}, http.StatusOK)
+	h.respond(w, tokens, http.StatusOK)
 }
 
 // SignupHandler godoc
@@ -81,7 +111,7 @@ func (h *handlerService) LoginHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param body body service.UserSignupParams true "Signup request body"
-// @Success 201 {object} LoginSuccessResponse "Signup successful"
+// @Success 201 {object} LoginSuccessResponse "Signup successful. When email verification is required, only a message is returned."
 // @Failure 400 {object} ErrorResponse "Invalid input"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /signup [post]
@@ -95,15 +125,15 @@ func (h *handlerService) SignupHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	l.Debug().Str("username", body.Username).Msg("User signup attempt")
-	token, err := h.userService.Signup(body)
+	tokens, err := h.userService.Signup(body, r.Context())
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredentials) {
 			h.respond(w, errors.New("username already exists"), http.StatusBadRequest)
 			return
-		} else if errors.Is(err, service.ErrInvalidUserInput) {
+		} else if errors.Is(err, service.ErrInvalidUserInput) || errors.Is(err, validators.ErrInvalidEmail) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
-		} else if errors.Is(err, service.ErrPasswordTooShort) {
+		} else if errors.As(err, &validators.PasswordPolicyError{}) {
 			h.respond(w, err, http.StatusBadRequest)
 			return
 		}
@@ -112,7 +142,11 @@ func (h *handlerService) SignupHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	l.Info().Str("username", body.Username).Msg("User signup successful")
-	h.respond(w, map[string]string{"token": token}, http.StatusCreated)
+	if tokens.Token == "" {
+		h.respond(w, MessageResponse{Message: "Signup successful, please verify your email address before logging in"}, http.StatusCreated)
+		return
+	}
+	h.respond(w, tokens, http.StatusCreated)
 }
 
 // GetUserByIDHandler godoc
@@ -193,6 +227,9 @@ func (h *handlerService) UpdateUserHandler(w http.ResponseWriter, r *http.Reques
 		} else if errors.Is(err, service.ErrInvalidUserInput) {
 			h.respond(w, errors.New("username already exists"), http.StatusBadRequest)
 			return
+		} else if errors.Is(err, validators.ErrInvalidEmail) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
 		} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
 			h.respond(w, err, http.StatusForbidden)
 			return
@@ -239,3 +276,52 @@ func (h *handlerService) DeleteUserHandler(w http.ResponseWriter, r *http.Reques
 	l.Info().Msgf("User with ID %d deleted successfully", userID)
 	h.respond(w, nil, http.StatusNoContent)
 }
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"old-Passw0rd"`
+	NewPassword     string `json:"new_password" example:"n3w-Passw0rd"`
+}
+
+// ChangePasswordHandler godoc
+// @Summary Change Password
+// @Description Changes the password of the authenticated user. The current password is required.
+// @Description All existing sessions are revoked and a new token pair is returned.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body ChangePasswordRequest true "Change password request body"
+// @Success 200 {object} LoginSuccessResponse "Password changed"
+// @Failure 400 {object} ErrorResponse "Invalid input or password rejected by the policy"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Current password is incorrect"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /owners/password [put]
+func (h *handlerService) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ChangePasswordHandler")
+	userID := r.Context().Value(middleware.ContextKeyUserID).(uint)
+	var body ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	tokens, err := h.userService.ChangePassword(userID, body.CurrentPassword, body.NewPassword, r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			h.respond(w, errors.New("current password is incorrect"), http.StatusForbidden)
+			return
+		} else if errors.Is(err, service.ErrInvalidUserInput) || errors.Is(err, service.ErrPasswordReused) || errors.As(err, &validators.PasswordPolicyError{}) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		} else if errors.As(err, &service.UserNotFoundError{}) {
+			h.respond(w, err, http.StatusNotFound)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to change password")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Uint("userID", userID).Msg("Password changed successfully")
+	h.respond(w, tokens, http.StatusOK)
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+const defaultDueWithinDays = 30
+
+// ListVaccinationsHandler godoc
+// @Summary List Vaccinations
+// @Description Lists the vaccinations of a pet, most recent first.
+// @Tags Vaccination
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Success 200 {array} model.Vaccination "Vaccinations of the pet"
+// @Failure 400 {object} ErrorResponse "Invalid Pet ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vaccinations [get]
+func (h *handlerService) ListVaccinationsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ListVaccinationsHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	vaccinations, err := h.vaccinationService.ListVaccinations(petID, r.Context())
+	if err != nil {
+		h.respondVaccinationError(w, r, err)
+		return
+	}
+	h.respond(w, vaccinations, http.StatusOK)
+}
+
+// GetVaccinationHandler godoc
+// @Summary Get Vaccination
+// @Description Fetches one vaccination of a pet.
+// @Tags Vaccination
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param vaccinationID path int true "Vaccination ID"
+// @Success 200 {object} model.Vaccination "Vaccination fetched successfully"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet or vaccination not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vaccinations/{vaccinationID} [get]
+func (h *handlerService) GetVaccinationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetVaccinationHandler")
+	vars := mux.Vars(r)
+	petID, vaccinationID, err := h.vaccinationIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	vaccination, err := h.vaccinationService.GetVaccination(petID, vaccinationID, r.Context())
+	if err != nil {
+		h.respondVaccinationError(w, r, err)
+		return
+	}
+	h.respond(w, vaccination, http.StatusOK)
+}
+
+// CreateVaccinationHandler godoc
+// @Summary Record Vaccination
+// @Description Records a vaccine dose given to a pet.
+// @Description given_at defaults to now and administered_by_id to the current user.
+// @Description Requires the vaccinations:manage permission.
+// @Tags Vaccination
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.VaccinationParams true "Vaccination"
+// @Success 201 {object} model.Vaccination "Vaccination recorded"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vaccinations [post]
+func (h *handlerService) CreateVaccinationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside CreateVaccinationHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.VaccinationParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	vaccination, err := h.vaccinationService.AddVaccination(petID, body, r.Context())
+	if err != nil {
+		h.respondVaccinationError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("vaccinationID", vaccination.ID).Str("vaccine", vaccination.Vaccine).Msg("Vaccination recorded")
+	h.respond(w, vaccination, http.StatusCreated)
+}
+
+// UpdateVaccinationHandler godoc
+// @Summary Update Vaccination
+// @Description Corrects a recorded vaccination. Fields that are left out keep their value.
+// @Description An empty lot_number or a null next_due_at clears it.
+// @Description Requires the vaccinations:manage permission.
+// @Tags Vaccination
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param vaccinationID path int true "Vaccination ID"
+// @Param body body service.VaccinationParams true "Vaccination"
+// @Success 200 {object} model.Vaccination "Vaccination updated"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or vaccination not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vaccinations/{vaccinationID} [put]
+func (h *handlerService) UpdateVaccinationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside UpdateVaccinationHandler")
+	vars := mux.Vars(r)
+	petID, vaccinationID, err := h.vaccinationIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.VaccinationParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	vaccination, err := h.vaccinationService.UpdateVaccination(petID, vaccinationID, body, r.Context())
+	if err != nil {
+		h.respondVaccinationError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("vaccinationID", vaccinationID).Msg("Vaccination updated")
+	h.respond(w, vaccination, http.StatusOK)
+}
+
+// DeleteVaccinationHandler godoc
+// @Summary Delete Vaccination
+// @Description Deletes a vaccination recorded by mistake.
+// @Description Requires the vaccinations:manage permission.
+// @Tags Vaccination
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param vaccinationID path int true "Vaccination ID"
+// @Success 204 "Vaccination deleted"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or vaccination not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vaccinations/{vaccinationID} [delete]
+func (h *handlerService) DeleteVaccinationHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeleteVaccinationHandler")
+	vars := mux.Vars(r)
+	petID, vaccinationID, err := h.vaccinationIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.vaccinationService.DeleteVaccination(petID, vaccinationID, r.Context()); err != nil {
+		h.respondVaccinationError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("vaccinationID", vaccinationID).Msg("Vaccination deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// GetDueVaccinationsHandler godoc
+// @Summary Due Vaccinations
+// @Description Lists pets whose next vaccine dose is overdue or due within the given number of days, soonest first.
+// @Description Only the latest dose of each vaccine counts, so a booster that was already given clears the reminder.
+// @Description Requires the pets:read_all permission.
+// @Tags Vaccination
+// @Produce json
+// @Security BearerAuth
+// @Param within_days query int false "Window in days, defaults to 30"
+// @Success 200 {array} service.DueVaccination "Due and overdue vaccinations"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /staff/vaccinations/due [get]
+func (h *handlerService) GetDueVaccinationsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetDueVaccinationsHandler")
+	withinDays := defaultDueWithinDays
+	if v := r.URL.Query().Get("within_days"); v != "" {
+		var err error
+		if withinDays, err = strconv.Atoi(v); err != nil || withinDays < 0 {
+			h.respond(w, errors.New("within_days is not valid"), http.StatusBadRequest)
+			return
+		}
+	}
+	due, err := h.vaccinationService.GetDueVaccinations(time.Duration(withinDays)*24*time.Hour, r.Context())
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to fetch due vaccinations")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, due, http.StatusOK)
+}
+
+func (h *handlerService) respondVaccinationError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.VaccinationNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrVaccineRequired) || errors.Is(err, service.ErrVaccinationInFuture) ||
+		errors.Is(err, service.ErrNextDueBeforeGiven) || errors.Is(err, service.ErrAdministeredByNotStaff) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage vaccination")
+	h.respond(w, err, http.StatusInternalServerError)
+}
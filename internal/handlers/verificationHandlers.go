@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"Yk3x9Q0bXk1Yf5cK8sZ0pT4mR6uW9aD2gH5jL8nQwEr"`
+}
+
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" example:"john@doe.com"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"john@doe.com"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"Yk3x9Q0bXk1Yf5cK8sZ0pT4mR6uW9aD2gH5jL8nQwEr"`
+	NewPassword string `json:"new_password" example:"n3w-Passw0rd"`
+}
+
+// VerifyEmailHandler godoc
+// @Summary Verify Email
+// @Description Confirms the user's email address with the token from the verification mail.
+// @Description The mail links to FRONTEND_URL/verify-email?token=..., whose page posts the token here.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body VerifyEmailRequest true "Verify email request body"
+// @Success 204 "Email verified"
+// @Failure 400 {object} ErrorResponse "Invalid or expired token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /verify-email [post]
+func (h *handlerService) VerifyEmailHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside VerifyEmailHandler")
+	var body VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.VerifyEmail(body.Token, r.Context()); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			h.respond(w, service.ErrInvalidUserToken, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to verify email")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Msg("Email verified successfully")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+// ResendVerificationEmailHandler godoc
+// @Summary Resend Verification Email
+// @Description Sends a new verification link if an unverified account uses the given email address, at most once every 5 minutes per account.
+// @Description The response is the same whether or not the address is known, so it also works for users who cannot log in yet.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ResendVerificationEmailRequest true "Resend verification email request body"
+// @Success 202 {object} MessageResponse "Verification email sent if the account needs one"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /verify-email/resend [post]
+func (h *handlerService) ResendVerificationEmailHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ResendVerificationEmailHandler")
+	var body ResendVerificationEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.ResendVerificationEmail(body.Email, r.Context()); err != nil {
+		if errors.Is(err, service.ErrInvalidUserInput) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to resend verification email")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, MessageResponse{Message: "If an unverified account uses this email address, a verification link has been sent"}, http.StatusAccepted)
+}
+
+// ForgotPasswordHandler godoc
+// @Summary Forgot Password
+// @Description Sends a password reset link if an account uses the given email address.
+// @Description The response is the same whether or not the address is known.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ForgotPasswordRequest true "Forgot password request body"
+// @Success 202 {object} MessageResponse "Reset link sent if the account exists"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /password/forgot [post]
+func (h *handlerService) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ForgotPasswordHandler")
+	var body ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.RequestPasswordReset(body.Email, r.Context()); err != nil {
+		if errors.Is(err, service.ErrInvalidUserInput) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to request password reset")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	h.respond(w, MessageResponse{Message: "If an account uses this email address, a reset link has been sent"}, http.StatusAccepted)
+}
+
+// ResetPasswordHandler godoc
+// @Summary Reset Password
+// @Description Sets a new password with the token from the reset mail and logs the user out of every device.
+// @Description The mail links to FRONTEND_URL/reset-password?token=..., whose page asks for the new password and posts it here.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ResetPasswordRequest true "Reset password request body"
+// @Success 204 "Password reset"
+// @Failure 400 {object} ErrorResponse "Invalid input or expired token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /password/reset [post]
+func (h *handlerService) ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside ResetPasswordHandler")
+	var body ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.userService.ResetPassword(body.Token, body.NewPassword, r.Context()); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			h.respond(w, service.ErrInvalidUserToken, http.StatusBadRequest)
+			return
+		} else if errors.As(err, &validators.PasswordPolicyError{}) || errors.Is(err, service.ErrPasswordReused) {
+			h.respond(w, err, http.StatusBadRequest)
+			return
+		}
+		l.Error().Err(err).Msg("Failed to reset password")
+		h.respond(w, err, http.StatusInternalServerError)
+		return
+	}
+	l.Info().Msg("Password reset successfully")
+	h.respond(w, nil, http.StatusNoContent)
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// GetVisitNoteHandler godoc
+// @Summary Get Visit Note
+// @Description Fetches the full clinical note of an appointment, including its addenda.
+// @Description Requires the visit_notes:read permission.
+// @Tags Visit Note
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} model.VisitNote "Visit note"
+// @Failure 400 {object} ErrorResponse "Invalid appointment ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Appointment or visit note not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /appointments/{id}/visit-note [get]
+func (h *handlerService) GetVisitNoteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetVisitNoteHandler")
+	vars := mux.Vars(r)
+	appointmentID, err := h.appointmentIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	note, err := h.visitNoteService.GetVisitNote(appointmentID, r.Context())
+	if err != nil {
+		h.respondVisitNoteError(w, r, err)
+		return
+	}
+	h.respond(w, note, http.StatusOK)
+}
+
+// SaveVisitNoteHandler godoc
+// @Summary Save Visit Note
+// @Description Creates or replaces the draft clinical note of an appointment in SOAP form.
+// @Description A signed note cannot be changed; add an addendum instead.
+// @Description Requires the visit_notes:write permission.
+// @Tags Visit Note
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Appointment ID"
+// @Param body body service.VisitNoteParams true "Visit note"
+// @Success 200 {object} model.VisitNote "Visit note saved"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Appointment not found"
+// @Failure 409 {object} ErrorResponse "Visit note is signed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /appointments/{id}/visit-note [put]
+func (h *handlerService) SaveVisitNoteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SaveVisitNoteHandler")
+	vars := mux.Vars(r)
+	appointmentID, err := h.appointmentIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.VisitNoteParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	note, err := h.visitNoteService.SaveVisitNote(appointmentID, body, r.Context())
+	if err != nil {
+		h.respondVisitNoteError(w, r, err)
+		return
+	}
+	l.Info().Uint("appointmentID", appointmentID).Uint("visitNoteID", note.ID).Msg("Visit note saved")
+	h.respond(w, note, http.StatusOK)
+}
+
+// SignVisitNoteHandler godoc
+// @Summary Sign Visit Note
+// @Description Signs the clinical note of an appointment. It is locked from then on, becomes visible to the owner as a summary and its vitals are added to the pet's vitals history.
+// @Description Requires the visit_notes:write permission.
+// @Tags Visit Note
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} model.VisitNote "Visit note signed"
+// @Failure 400 {object} ErrorResponse "Visit note incomplete"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Appointment or visit note not found"
+// @Failure 409 {object} ErrorResponse "Visit note already signed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /appointments/{id}/visit-note/sign [post]
+func (h *handlerService) SignVisitNoteHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside SignVisitNoteHandler")
+	vars := mux.Vars(r)
+	appointmentID, err := h.appointmentIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	note, err := h.visitNoteService.SignVisitNote(appointmentID, r.Context())
+	if err != nil {
+		h.respondVisitNoteError(w, r, err)
+		return
+	}
+	l.Info().Uint("appointmentID", appointmentID).Uint("visitNoteID", note.ID).Msg("Visit note signed")
+	h.respond(w, note, http.StatusOK)
+}
+
+// AddVisitNoteAddendumHandler godoc
+// @Summary Add Addendum
+// @Description Appends a correction to a signed clinical note.
+// @Description Requires the visit_notes:write permission.
+// @Tags Visit Note
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Appointment ID"
+// @Param body body service.AddendumParams true "Addendum"
+// @Success 201 {object} model.VisitNoteAddendum "Addendum added"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Appointment or visit note not found"
+// @Failure 409 {object} ErrorResponse "Visit note not signed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /appointments/{id}/visit-note/addenda [post]
+func (h *handlerService) AddVisitNoteAddendumHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside AddVisitNoteAddendumHandler")
+	vars := mux.Vars(r)
+	appointmentID, err := h.appointmentIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.AddendumParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	addendum, err := h.visitNoteService.AddAddendum(appointmentID, body, r.Context())
+	if err != nil {
+		h.respondVisitNoteError(w, r, err)
+		return
+	}
+	l.Info().Uint("appointmentID", appointmentID).Uint("addendumID", addendum.ID).Msg("Visit note addendum added")
+	h.respond(w, addendum, http.StatusCreated)
+}
+
+// GetVisitSummaryHandler godoc
+// @Summary Get Visit Summary
+// @Description Fetches the owner facing summary of a signed visit note: vitals, diagnoses and the treatment plan.
+// @Tags Visit Note
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} service.VisitSummary "Visit summary"
+// @Failure 400 {object} ErrorResponse "Invalid appointment ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Appointment or signed visit note not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /appointments/{id}/visit-summary [get]
+func (h *handlerService) GetVisitSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetVisitSummaryHandler")
+	vars := mux.Vars(r)
+	appointmentID, err := h.appointmentIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	summary, err := h.visitNoteService.GetVisitSummary(appointmentID, r.Context())
+	if err != nil {
+		h.respondVisitNoteError(w, r, err)
+		return
+	}
+	h.respond(w, summary, http.StatusOK)
+}
+
+func (h *handlerService) respondVisitNoteError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.AppointmentNotFoundError{}) || errors.As(err, &service.PetNotFoundError{}) ||
+		errors.As(err, &service.VisitNoteNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrVisitNoteSigned) || errors.Is(err, service.ErrVisitNoteNotSigned) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrVisitNoteIncomplete) || errors.Is(err, service.ErrAddendumTextRequired) ||
+		errors.Is(err, service.ErrInvalidVitals) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage visit note")
+	h.respond(w, err, http.StatusInternalServerError)
+}
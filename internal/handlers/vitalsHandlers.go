@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/service"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
+)
+
+// GetVitalsSeriesHandler godoc
+// @Summary Get Vitals Series
+// @Description Returns a pet's weight and vitals readings, oldest first, for growth and weight charts.
+// @Description The trend compares the first and latest weight, and flags a loss of 5% or more within 30 days.
+// @Tags Vitals
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param from query string false "Only readings from this time on (RFC 3339)"
+// @Param to query string false "Only readings up to this time (RFC 3339)"
+// @Success 200 {object} service.VitalsSeries "Vitals series"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Resource not owned"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vitals [get]
+func (h *handlerService) GetVitalsSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside GetVitalsSeriesHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var params service.VitalsSeriesParams
+	query := r.URL.Query()
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			h.respond(w, errors.New("from is not a valid RFC 3339 time"), http.StatusBadRequest)
+			return
+		}
+		params.From = &from
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			h.respond(w, errors.New("to is not a valid RFC 3339 time"), http.StatusBadRequest)
+			return
+		}
+		params.To = &to
+	}
+	series, err := h.vitalsService.GetVitalsSeries(petID, params, r.Context())
+	if err != nil {
+		h.respondVitalsError(w, r, err)
+		return
+	}
+	h.respond(w, series, http.StatusOK)
+}
+
+// RecordVitalsHandler godoc
+// @Summary Record Vitals
+// @Description Records a weight or vitals reading for a pet. Readings from visit notes are added automatically when the note is signed.
+// @Description Requires the vitals:record permission.
+// @Tags Vitals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param body body service.VitalsParams true "Vitals"
+// @Success 201 {object} model.VitalsMeasurement "Vitals recorded"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vitals [post]
+func (h *handlerService) RecordVitalsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside RecordVitalsHandler")
+	vars := mux.Vars(r)
+	petID, err := h.petIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	var body service.VitalsParams
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	measurement, err := h.vitalsService.RecordVitals(petID, body, r.Context())
+	if err != nil {
+		h.respondVitalsError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("measurementID", measurement.ID).Msg("Vitals recorded")
+	h.respond(w, measurement, http.StatusCreated)
+}
+
+// DeleteVitalsHandler godoc
+// @Summary Delete Vitals
+// @Description Deletes a reading entered by mistake. Readings that come from a signed visit note cannot be deleted.
+// @Description Requires the vitals:record permission.
+// @Tags Vitals
+// @Security BearerAuth
+// @Param id path int true "Pet ID"
+// @Param measurementID path int true "Measurement ID"
+// @Success 204 "Vitals deleted"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Pet or measurement not found"
+// @Failure 409 {object} ErrorResponse "Measurement belongs to a visit note"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /pets/{id}/vitals/{measurementID} [delete]
+func (h *handlerService) DeleteVitalsHandler(w http.ResponseWriter, r *http.Request) {
+	l := zerolog.Ctx(r.Context())
+	l.Trace().Msg("Inside DeleteVitalsHandler")
+	vars := mux.Vars(r)
+	petID, measurementID, err := h.measurementIDValidate(&vars)
+	if err != nil {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := h.vitalsService.DeleteVitals(petID, measurementID, r.Context()); err != nil {
+		h.respondVitalsError(w, r, err)
+		return
+	}
+	l.Info().Uint("petID", petID).Uint("measurementID", measurementID).Msg("Vitals deleted")
+	h.respond(w, nil, http.StatusNoContent)
+}
+
+func (h *handlerService) respondVitalsError(w http.ResponseWriter, r *http.Request, err error) {
+	l := zerolog.Ctx(r.Context())
+	if errors.As(err, &service.PetNotFoundError{}) || errors.As(err, &service.VitalsMeasurementNotFoundError{}) {
+		h.respond(w, err, http.StatusNotFound)
+		return
+	} else if errors.As(err, &validators.ResourceNotOwnedError{}) {
+		h.respond(w, err, http.StatusForbidden)
+		return
+	} else if errors.Is(err, service.ErrMeasurementFromVisitNote) {
+		h.respond(w, err, http.StatusConflict)
+		return
+	} else if errors.Is(err, service.ErrInvalidVitals) || errors.Is(err, service.ErrNoVitals) || errors.Is(err, service.ErrMeasurementInFuture) {
+		h.respond(w, err, http.StatusBadRequest)
+		return
+	}
+	l.Error().Err(err).Msg("Failed to manage vitals")
+	h.respond(w, err, http.StatusInternalServerError)
+}
@@ -0,0 +1,201 @@
+package lockout
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+)
+
+// Entry is the failed attempt state of a single key.
+type Entry struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// Store keeps failed login counters. Implementations must make RecordFailure
+// atomic so that concurrent attempts are all counted.
+type Store interface {
+	Get(ctx context.Context, key string) (Entry, error)
+	// RecordFailure increments the counter of key. The counter starts over
+	// when neither the last failure nor the lock is more recent than
+	// resetBefore.
+	RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (Entry, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// LockedError is returned when a username or client IP is temporarily locked.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e LockedError) Error() string {
+	return "too many failed login attempts, try again later"
+}
+
+// Policy controls after how many failures a key is locked and for how long.
+// Each further failure doubles the lock, up to MaxLock.
+type Policy struct {
+	MaxUserFailures int
+	MaxIPFailures   int
+	BaseLock        time.Duration
+	MaxLock         time.Duration
+	Window          time.Duration
+}
+
+// DefaultPolicy reads the LOGIN_* environment variables.
+var DefaultPolicy = Policy{
+	MaxUserFailures: intFromEnv("LOGIN_MAX_FAILURES", 5),
+	MaxIPFailures:   intFromEnv("LOGIN_MAX_FAILURES_PER_IP", 20),
+	BaseLock:        durationFromEnv("LOGIN_LOCKOUT_BASE", time.Minute),
+	MaxLock:         durationFromEnv("LOGIN_LOCKOUT_MAX", time.Hour),
+	Window:          durationFromEnv("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+}
+
+// Limiter applies a Policy to the failures recorded in a Store, tracking
+// usernames and client IPs separately.
+type Limiter struct {
+	Store  Store
+	Policy Policy
+	now    func() time.Time
+}
+
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{Store: store, Policy: policy, now: time.Now}
+}
+
+func UserKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func IPKey(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		ip = parsed.String()
+	}
+	return "ip:" + ip
+}
+
+// Check returns a LockedError if the username or the IP is currently locked.
+func (l *Limiter) Check(ctx context.Context, username, ip string) error {
+	now := l.now()
+	var retryAfter time.Duration
+	for _, key := range l.keys(username, ip) {
+		entry, err := l.Store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if wait := entry.LockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		return LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt for the username and the IP and locks
+// whichever of them crossed its threshold.
+func (l *Limiter) RecordFailure(ctx context.Context, username, ip string) error {
+	now := l.now()
+	resetBefore := now.Add(-l.Policy.Window)
+	for _, key := range l.keys(username, ip) {
+		entry, err := l.Store.RecordFailure(ctx, key, now, resetBefore)
+		if err != nil {
+			return err
+		}
+		threshold := l.Policy.MaxUserFailures
+		if strings.HasPrefix(key, "ip:") {
+			threshold = l.Policy.MaxIPFailures
+		}
+		if entry.Failures < threshold {
+			continue
+		}
+		if err := l.Store.Lock(ctx, key, now.Add(l.lockDuration(entry.Failures-threshold))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordSuccess clears the username counter. The IP counter is left alone so
+// that an attacker cannot reset it by logging into an account of their own.
+func (l *Limiter) RecordSuccess(ctx context.Context, username string) error {
+	return l.Store.Reset(ctx, UserKey(username))
+}
+
+func (l *Limiter) UnlockUser(ctx context.Context, username string) error {
+	return l.Store.Reset(ctx, UserKey(username))
+}
+
+func (l *Limiter) UnlockIP(ctx context.Context, ip string) error {
+	return l.Store.Reset(ctx, IPKey(ip))
+}
+
+func (l *Limiter) lockDuration(excess int) time.Duration {
+	lock := l.Policy.BaseLock
+	for i := 0; i < excess && lock < l.Policy.MaxLock; i++ {
+		lock *= 2
+	}
+	if lock > l.Policy.MaxLock {
+		lock = l.Policy.MaxLock
+	}
+	return lock
+}
+
+func (l *Limiter) keys(username, ip string) []string {
+	keys := []string{UserKey(username)}
+	if ip != "" {
+		keys = append(keys, IPKey(ip))
+	}
+	return keys
+}
+
+var once sync.Once
+
+var limiter *Limiter
+
+// Get returns the process wide limiter. LOGIN_ATTEMPT_STORE selects the
+// backend: "postgres" shares counters between instances, "memory" (the
+// default) keeps them in the process.
+func Get() *Limiter {
+	once.Do(func() {
+		var store Store
+		switch os.Getenv("LOGIN_ATTEMPT_STORE") {
+		case "postgres":
+			store = NewPostgresStore(initializers.DB)
+		default:
+			store = NewMemoryStore()
+		}
+		limiter = NewLimiter(store, DefaultPolicy)
+	})
+	return limiter
+}
+
+// Set replaces the process wide limiter, e.g. with one using a MemoryStore in
+// tests.
+func Set(l *Limiter) {
+	once.Do(func() {})
+	limiter = l
+}
+
+func intFromEnv(name string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,71 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepThreshold is the number of keys above which stale entries are dropped.
+const sweepThreshold = 10000
+
+// MemoryStore keeps counters in the process. They are lost on restart and not
+// shared between instances.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]Entry{}}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key], nil
+}
+
+func (s *MemoryStore) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) > sweepThreshold {
+		s.sweep(now, resetBefore)
+	}
+	entry := s.entries[key]
+	if isStale(entry, resetBefore) {
+		entry = Entry{}
+	}
+	entry.Failures++
+	entry.LastFailure = now
+	s.entries[key] = entry
+	return entry, nil
+}
+
+func (s *MemoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := s.entries[key]
+	entry.LockedUntil = until
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStore) sweep(now, resetBefore time.Time) {
+	for key, entry := range s.entries {
+		if isStale(entry, resetBefore) && entry.LockedUntil.Before(now) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func isStale(entry Entry, resetBefore time.Time) bool {
+	return entry.LastFailure.Before(resetBefore) && entry.LockedUntil.Before(resetBefore)
+}
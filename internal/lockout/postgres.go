@@ -0,0 +1,72 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"gorm.io/gorm"
+)
+
+// PostgresStore keeps counters in the login_attempts table so that every
+// instance of the API sees the same lockouts.
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (Entry, error) {
+	var attempt model.LoginAttempt
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&attempt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Entry{}, nil
+		}
+		return Entry{}, err
+	}
+	return toEntry(attempt), nil
+}
+
+// RecordFailure increments the counter in a single upsert so that concurrent
+// failures cannot overwrite each other.
+func (s *PostgresStore) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (Entry, error) {
+	var attempt model.LoginAttempt
+	err := s.db.WithContext(ctx).Raw(`
+		INSERT INTO login_attempts (key, failures, last_failure_at, updated_at)
+		VALUES (?, 1, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN login_attempts.last_failure_at < ? AND COALESCE(login_attempts.locked_until, login_attempts.last_failure_at) < ?
+				THEN 1
+				ELSE login_attempts.failures + 1
+			END,
+			last_failure_at = EXCLUDED.last_failure_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING key, failures, last_failure_at, locked_until, updated_at`,
+		key, now, now, resetBefore, resetBefore).Scan(&attempt).Error
+	if err != nil {
+		return Entry{}, err
+	}
+	return toEntry(attempt), nil
+}
+
+func (s *PostgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return s.db.WithContext(ctx).Model(&model.LoginAttempt{}).
+		Where("key = ?", key).
+		Updates(map[string]interface{}{"locked_until": until, "updated_at": time.Now()}).Error
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("key = ?", key).Delete(&model.LoginAttempt{}).Error
+}
+
+func toEntry(attempt model.LoginAttempt) Entry {
+	entry := Entry{Failures: attempt.Failures, LastFailure: attempt.LastFailureAt}
+	if attempt.LockedUntil != nil {
+		entry.LockedUntil = *attempt.LockedUntil
+	}
+	return entry
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/xid"
+)
+
+// FileMailer writes every message as an .eml file into Dir instead of
+// sending it. It is meant for local development.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.Dir, os.ModePerm); err != nil {
+		return fmt.Errorf("creating mail directory %s: %w", m.Dir, err)
+	}
+	from := m.From
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+	data, err := formatMessage(from, msg)
+	if err != nil {
+		return fmt.Errorf("writing mail: %w", err)
+	}
+	path := filepath.Join(m.Dir, xid.New().String()+".eml")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing mail to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends transactional mail such as verification and password reset
+// links.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var once sync.Once
+
+var mailer Mailer
+
+// Get returns the process wide mailer selected by the MAILER environment
+// variable: "smtp", "memory" or "file" (the default, writing to MAIL_DIR).
+func Get() Mailer {
+	once.Do(func() {
+		switch os.Getenv("MAILER") {
+		case "smtp":
+			port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+			if err != nil {
+				port = 587
+			}
+			mailer = &SMTPMailer{
+				Host:     os.Getenv("SMTP_HOST"),
+				Port:     port,
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("SMTP_FROM"),
+			}
+		case "memory":
+			mailer = NewMemoryMailer()
+		default:
+			dir := os.Getenv("MAIL_DIR")
+			if dir == "" {
+				dir = "mail"
+			}
+			mailer = &FileMailer{Dir: dir, From: os.Getenv("SMTP_FROM")}
+		}
+	})
+
+	return mailer
+}
+
+// Set replaces the process wide mailer, e.g. with a MemoryMailer in tests.
+func Set(m Mailer) {
+	once.Do(func() {})
+	mailer = m
+}
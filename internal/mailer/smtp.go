@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+var ErrInvalidAddress = errors.New("invalid email address")
+
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("sending mail: %w", ErrInvalidAddress)
+	}
+	data, err := formatMessage(m.From, msg)
+	if err != nil {
+		return fmt.Errorf("sending mail to %s: %w", to.Address, err)
+	}
+	addr := fmt.Sprintf("%s:%d", m.Host, m.Port)
+	if err := smtp.SendMail(addr, auth, envelopeAddress(m.From), []string{to.Address}, data); err != nil {
+		return fmt.Errorf("sending mail to %s: %w", to.Address, err)
+	}
+	return nil
+}
+
+// formatMessage renders msg as a plain text RFC 5322 message. The addresses
+// must parse as single addresses, which rules out line breaks, and the
+// subject is Q-encoded, so no header can smuggle in another one.
+func formatMessage(from string, msg Message) ([]byte, error) {
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("sender %q: %w", from, ErrInvalidAddress)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, ErrInvalidAddress
+	}
+	var b strings.Builder
+	b.WriteString("From: " + fromAddress.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String()), nil
+}
+
+// envelopeAddress returns the bare address of a From value such as
+// "Clinic <no-reply@clinic.test>".
+func envelopeAddress(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		return address.Address
+	}
+	return from
+}
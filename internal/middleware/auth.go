@@ -4,7 +4,9 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
 	"github.com/rs/zerolog"
@@ -13,15 +15,22 @@ import (
 type contextKey string
 
 const (
-	ContextKeyUsername contextKey = "username"
-	ContextKeyRole     contextKey = "role"
-	ContextKeyUserID   contextKey = "user_id"
+	ContextKeyUsername  contextKey = "username"
+	ContextKeyRole      contextKey = "role"
+	ContextKeyUserID    contextKey = "user_id"
+	ContextKeySessionID contextKey = "session_id"
 )
 
+// ValidateJWT authenticates a request by its bearer access token, or by an
+// API key when the X-API-Key header is set.
 func ValidateJWT(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		l := zerolog.Ctx(r.Context())
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			validateAPIKey(w, r, next, key)
+			return
+		}
 		token := r.Header.Get("Authorization")
 		if token == "" {
 			l.Debug().Msg("Authorization header is missing")
@@ -62,57 +71,61 @@ func ValidateJWT(next http.Handler) http.Handler {
 			return
 		}
 
+		sessionIDFloat, ok := claims["sid"].(float64)
+		sessionID := uint(sessionIDFloat)
+		if !ok || sessionID == 0 {
+			l.Debug().Uint("sid", sessionID).Msg("Invalid session ID in token claims")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("{\"error\": \"Unauthorized: invalid token\"}"))
+			return
+		}
+
+		impersonationID, impersonatorID := impersonationClaims(claims)
+		var active bool
+		if impersonationID != 0 {
+			active, err = isImpersonationActive(impersonationID, impersonatorID, userID, sessionID)
+		} else {
+			active, err = isSessionActive(sessionID, userID)
+		}
+		if err != nil {
+			l.Error().Err(err).Uint("sid", sessionID).Msg("Failed to look up session")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("{\"error\": \"Internal server error\"}"))
+			return
+		}
+		if !active {
+			l.Debug().Uint("sid", sessionID).Msg("Session revoked or expired")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("{\"error\": \"Unauthorized: session revoked\"}"))
+			return
+		}
+
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, ContextKeyUsername, username)
 		ctx = context.WithValue(ctx, ContextKeyRole, role)
 		ctx = context.WithValue(ctx, ContextKeyUserID, userID)
+		ctx = context.WithValue(ctx, ContextKeySessionID, sessionID)
+		if impersonationID != 0 {
+			ctx = withImpersonation(ctx, impersonationID, impersonatorID, userID)
+			auditImpersonation(w, r.WithContext(ctx), next, impersonationID)
+			return
+		}
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
 
 	})
 }
-func ProtectAdminRoute(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		role, ok := r.Context().Value(ContextKeyRole).(string)
-		l := zerolog.Ctx(r.Context())
-		l.Trace().Msg("Inside ProtectAdminRoute middleware")
-		l.Debug().Str("role", role).Msg("Checking user role for admin access")
-		if !ok || role != model.UserTypeAdmin {
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte("{\"error\": \"Forbidden: admin access required\"}"))
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
-}
 
-func ProtectStaffRoute(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		role, ok := r.Context().Value(ContextKeyRole).(string)
-		l := zerolog.Ctx(r.Context())
-		l.Trace().Msg("Inside ProtectStaffRoute middleware")
-		l.Debug().Str("role", role).Msg("Checking user role for staff access")
-		if !ok || (role != model.UserTypeStaff && role != model.UserTypeAdmin) {
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte("{\"error\": \"Forbidden: staff access required\"}"))
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+// isSessionActive reports whether the session behind an access token still
+// exists and has neither been revoked nor expired.
+func isSessionActive(sessionID, userID uint) (bool, error) {
+	var count int64
+	tx := initializers.DB.Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, userID, time.Now()).
+		Count(&count)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return count > 0, nil
 }
-
-func ProtectOwnerRoute(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		role, ok := r.Context().Value(ContextKeyRole).(string)
-		l := zerolog.Ctx(r.Context())
-		l.Trace().Msg("Inside ProtectOwnerRoute middleware")
-		l.Debug().Str("role", role).Msg("Checking user role for owner access")
-		if !ok || (role != model.UserTypeOwner && role != model.UserTypeStaff && role != model.UserTypeAdmin) {
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte("{\"error\": \"Forbidden: owner access required\"}"))
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
-}
\ No newline at end of file
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	ContextKeyClientIP  contextKey = "client_ip"
+	ContextKeyUserAgent contextKey = "user_agent"
+)
+
+var (
+	trustedProxiesOnce sync.Once
+	trustedProxies     []*net.IPNet
+)
+
+// ClientInfo stores the caller's IP address and user agent in the request
+// context. The app runs behind nginx, which sets X-Real-IP and X-Forwarded-For;
+// those headers are only believed when the request comes from one of the
+// proxies listed in TRUSTED_PROXIES.
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx = context.WithValue(ctx, ContextKeyClientIP, clientIP(r))
+		ctx = context.WithValue(ctx, ContextKeyUserAgent, r.UserAgent())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// clientIP returns the address of the peer, or, when the peer is a trusted
+// proxy, the client address it forwarded. X-Forwarded-For is read from the
+// right so that entries a client made up itself are never used.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrustedProxy(host) {
+		return host
+	}
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(hops[i])
+		if net.ParseIP(ip) == nil {
+			break
+		}
+		if !isTrustedProxy(ip) {
+			return ip
+		}
+	}
+	return host
+}
+
+// isTrustedProxy reports whether ip is covered by TRUSTED_PROXIES, a comma
+// separated list of IP addresses and CIDR ranges, e.g. "172.28.0.10,10.0.0.0/8".
+// Malformed entries are skipped.
+func isTrustedProxy(ip string) bool {
+	trustedProxiesOnce.Do(func() {
+		for _, entry := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if !strings.Contains(entry, "/") {
+				if strings.Contains(entry, ":") {
+					entry += "/128"
+				} else {
+					entry += "/32"
+				}
+			}
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				trustedProxies = append(trustedProxies, network)
+			}
+		}
+	})
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/authz"
+	"github.com/rs/zerolog"
+)
+
+// RequirePermission only lets requests through whose role grants permission.
+// It must run after ValidateJWT.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(ContextKeyRole).(string)
+			l := zerolog.Ctx(r.Context())
+			l.Trace().Msg("Inside RequirePermission middleware")
+			l.Debug().Str("role", role).Str("permission", permission).Msg("Checking permission")
+			allowed, err := hasPermission(r.Context(), permission)
+			if err != nil {
+				l.Error().Err(err).Str("role", role).Msg("Failed to look up role permissions")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte("{\"error\": \"Internal server error\"}"))
+				return
+			}
+			if !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte("{\"error\": \"Forbidden: missing permission " + permission + "\"}"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HasPermission reports whether the authenticated user's role grants
+// permission. Lookup errors are logged and treated as a denial.
+func HasPermission(ctx context.Context, permission string) bool {
+	allowed, err := hasPermission(ctx, permission)
+	if err != nil {
+		l := zerolog.Ctx(ctx)
+		l.Error().Err(err).Msg("Failed to look up role permissions")
+		return false
+	}
+	return allowed
+}
+
+// hasPermission checks the role in ctx and, for requests made with an API
+// key, also the key's scopes.
+func hasPermission(ctx context.Context, permission string) (bool, error) {
+	role, ok := ctx.Value(ContextKeyRole).(string)
+	if !ok {
+		return false, nil
+	}
+	if scopes, ok := ctx.Value(ContextKeyAPIKeyScopes).([]string); ok && !slices.Contains(scopes, permission) {
+		return false, nil
+	}
+	return authz.RoleHasPermission(role, permission)
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey lets an integration or kiosk act as a user without logging in. Only
+// the key's hash is stored; Prefix is kept in clear text so that a key can be
+// recognised in lists and logs. Scopes, when set, narrow the permissions of
+// the user's role for requests made with the key.
+type APIKey struct {
+	gorm.Model
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"not null" example:"Lobby kiosk"`
+	Prefix     string     `json:"prefix" gorm:"not null;index" example:"pck_3kT9xQ2a"`
+	KeyHash    string     `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json" example:"appointments:read_all"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	User       User       `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
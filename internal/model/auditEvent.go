@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	AuditActionMicrochipLookup       string = "microchip.lookup"
+	AuditActionMicrochipLookupFailed string = "microchip.lookup_failed"
+)
+
+// AuditEvent records a sensitive action, such as revealing who owns a found
+// animal, together with who did it and from where.
+type AuditEvent struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	UserID       uint      `json:"user_id" gorm:"not null;index"`
+	APIKeyID     *uint     `json:"api_key_id,omitempty"`
+	Action       string    `json:"action" gorm:"not null;index" example:"microchip.lookup"`
+	ResourceType string    `json:"resource_type,omitempty" example:"pet"`
+	ResourceID   *uint     `json:"resource_id,omitempty" example:"42"`
+	Detail       string    `json:"detail" example:"985112345678901"`
+	ClientIP     string    `json:"client_ip" example:"203.0.113.7"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+	User         User      `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import "time"
+
+// Species is an entry of the reference catalog pets are validated against.
+// Aliases are other spellings that are accepted and stored as Name, e.g.
+// "canine" for "Dog".
+type Species struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex" example:"Dog"`
+	Aliases   []string  `json:"aliases" gorm:"serializer:json" example:"canine,puppy"`
+	Breeds    []Breed   `json:"breeds,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Breed struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	SpeciesID uint      `json:"species_id" gorm:"not null;uniqueIndex:idx_breed_species_name"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_breed_species_name" example:"Golden Retriever"`
+	Aliases   []string  `json:"aliases" gorm:"serializer:json" example:"golden,goldie"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultCatalog is seeded into an empty catalog on startup. Admins can add
+// to it afterwards.
+var DefaultCatalog = []Species{
+	{Name: "Dog", Aliases: []string{"dogs", "canine", "puppy"}, Breeds: []Breed{
+		{Name: "Mixed Breed", Aliases: []string{"mixed", "mix", "mutt", "crossbreed"}},
+		{Name: "Unknown"},
+		{Name: "Beagle"},
+		{Name: "Border Collie", Aliases: []string{"collie"}},
+		{Name: "Boxer"},
+		{Name: "Bulldog", Aliases: []string{"english bulldog"}},
+		{Name: "Chihuahua"},
+		{Name: "Dachshund", Aliases: []string{"sausage dog", "wiener dog"}},
+		{Name: "French Bulldog", Aliases: []string{"frenchie"}},
+		{Name: "German Shepherd", Aliases: []string{"alsatian", "gsd"}},
+		{Name: "Golden Retriever", Aliases: []string{"golden"}},
+		{Name: "Labrador Retriever", Aliases: []string{"labrador", "lab"}},
+		{Name: "Poodle"},
+		{Name: "Rottweiler"},
+		{Name: "Shih Tzu"},
+		{Name: "Siberian Husky", Aliases: []string{"husky"}},
+		{Name: "Yorkshire Terrier", Aliases: []string{"yorkie"}},
+	}},
+	{Name: "Cat", Aliases: []string{"cats", "feline", "kitten"}, Breeds: []Breed{
+		{Name: "Mixed Breed", Aliases: []string{"mixed", "mix"}},
+		{Name: "Unknown"},
+		{Name: "Domestic Shorthair", Aliases: []string{"dsh"}},
+		{Name: "Domestic Longhair", Aliases: []string{"dlh"}},
+		{Name: "Bengal"},
+		{Name: "British Shorthair"},
+		{Name: "Maine Coon"},
+		{Name: "Persian"},
+		{Name: "Ragdoll"},
+		{Name: "Siamese"},
+		{Name: "Sphynx"},
+	}},
+	{Name: "Rabbit", Aliases: []string{"rabbits", "bunny"}, Breeds: []Breed{
+		{Name: "Mixed Breed", Aliases: []string{"mixed", "mix"}},
+		{Name: "Unknown"},
+		{Name: "Holland Lop"},
+		{Name: "Lionhead"},
+		{Name: "Netherland Dwarf"},
+	}},
+	{Name: "Guinea Pig", Aliases: []string{"guinea pigs", "cavy"}, Breeds: []Breed{
+		{Name: "Mixed Breed", Aliases: []string{"mixed", "mix"}},
+		{Name: "Unknown"},
+		{Name: "Abyssinian"},
+		{Name: "American"},
+		{Name: "Peruvian"},
+	}},
+	{Name: "Bird", Aliases: []string{"birds", "avian"}, Breeds: []Breed{
+		{Name: "Unknown"},
+		{Name: "Budgerigar", Aliases: []string{"budgie", "parakeet"}},
+		{Name: "Canary"},
+		{Name: "Cockatiel"},
+		{Name: "African Grey Parrot", Aliases: []string{"african grey"}},
+	}},
+	{Name: "Hamster", Aliases: []string{"hamsters"}, Breeds: []Breed{
+		{Name: "Unknown"},
+		{Name: "Syrian", Aliases: []string{"golden hamster"}},
+		{Name: "Dwarf"},
+	}},
+	{Name: "Ferret", Aliases: []string{"ferrets"}, Breeds: []Breed{
+		{Name: "Unknown"},
+	}},
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Access levels of household members, from least to most. Each level
+// includes the ones before it.
+const (
+	HouseholdAccessView   string = "view"
+	HouseholdAccessBook   string = "book"
+	HouseholdAccessManage string = "manage"
+)
+
+var householdAccessRank = map[string]int{
+	HouseholdAccessView:   1,
+	HouseholdAccessBook:   2,
+	HouseholdAccessManage: 3,
+}
+
+func IsValidHouseholdAccess(access string) bool {
+	_, ok := householdAccessRank[access]
+	return ok
+}
+
+// HouseholdAccessCovers reports whether a member with the granted access
+// level may do what needs the required one.
+func HouseholdAccessCovers(granted, required string) bool {
+	return IsValidHouseholdAccess(granted) && householdAccessRank[granted] >= householdAccessRank[required]
+}
+
+// Household lets several users, e.g. partners or a pet sitter, share access
+// to pets. Each pet still has a single owner, who decides whether it is
+// shared with one of their households.
+type Household struct {
+	gorm.Model
+	Name    string            `json:"name" gorm:"not null" example:"The Smiths"`
+	Members []HouseholdMember `json:"members,omitempty" gorm:"foreignKey:HouseholdID"`
+}
+
+type HouseholdMember struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	HouseholdID uint      `json:"household_id" gorm:"not null;uniqueIndex:idx_household_member"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_household_member;index"`
+	Access      string    `json:"access" gorm:"not null" enums:"view,book,manage" example:"book"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Name and Email are read from the member's user account.
+	Name      string    `json:"name" gorm:"->;-:migration"`
+	Email     string    `json:"email" gorm:"->;-:migration"`
+	Household Household `json:"-" gorm:"foreignKey:HouseholdID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      User      `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+const (
+	HouseholdInviteStatusPending   string = "pending"
+	HouseholdInviteStatusAccepted  string = "accepted"
+	HouseholdInviteStatusDeclined  string = "declined"
+	HouseholdInviteStatusCancelled string = "cancelled"
+)
+
+// HouseholdInvite asks whoever owns the account with Email to join a
+// household. It is stored whether or not such an account exists, so that
+// inviting does not reveal who is registered, and the invitee only becomes
+// a member by accepting it.
+type HouseholdInvite struct {
+	gorm.Model
+	HouseholdID uint       `json:"household_id" gorm:"not null;uniqueIndex:idx_household_invites_pending,where:status = 'pending' AND deleted_at IS NULL"`
+	Email       string     `json:"email" gorm:"not null;index;uniqueIndex:idx_household_invites_pending,expression:LOWER(email)" example:"partner@example.com"`
+	Access      string     `json:"access" gorm:"not null" enums:"view,book,manage" example:"book"`
+	InvitedByID uint       `json:"invited_by_id" gorm:"not null"`
+	Status      string     `json:"status" gorm:"not null;default:pending" enums:"pending,accepted,declined,cancelled" example:"pending"`
+	RespondedAt *time.Time `json:"responded_at"`
+	// HouseholdName is read from the household.
+	HouseholdName string    `json:"household_name" gorm:"->;-:migration" example:"The Smiths"`
+	Household     Household `json:"-" gorm:"foreignKey:HouseholdID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Impersonation records a staff member acting as an owner, e.g. to see what
+// the owner sees while on the phone with them.
+type Impersonation struct {
+	gorm.Model
+	ImpersonatorID uint                 `json:"impersonator_id" gorm:"not null;index"`
+	UserID         uint                 `json:"user_id" gorm:"not null;index"`
+	SessionID      uint                 `json:"-" gorm:"not null"`
+	Reason         string               `json:"reason" gorm:"not null" example:"Owner called about next week's appointment"`
+	ExpiresAt      time.Time            `json:"expires_at" gorm:"not null"`
+	EndedAt        *time.Time           `json:"ended_at"`
+	Events         []ImpersonationEvent `json:"events,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Impersonator   User                 `json:"-" gorm:"foreignKey:ImpersonatorID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User           User                 `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// ImpersonationEvent is one request made while impersonating.
+type ImpersonationEvent struct {
+	ID              uint      `json:"id" gorm:"primarykey"`
+	ImpersonationID uint      `json:"impersonation_id" gorm:"not null;index"`
+	Method          string    `json:"method" example:"GET"`
+	Path            string    `json:"path" example:"/pets"`
+	StatusCode      int       `json:"status_code" example:"200"`
+	CreatedAt       time.Time `json:"created_at"`
+}
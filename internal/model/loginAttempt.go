@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// LoginAttempt holds the failed login counter for one key, e.g. a username or
+// a client IP. It backs the Postgres lockout store.
+type LoginAttempt struct {
+	Key           string `gorm:"primarykey"`
+	Failures      int    `gorm:"not null;default:0"`
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	MergeKindUser string = "user"
+	MergeKindPet  string = "pet"
+)
+
+// MergeLog records a duplicate user or pet that was merged into another
+// record. Snapshot holds the duplicate as it was before the merge and Moved
+// counts the rows that were re-pointed, so a wrong merge can be undone by
+// hand.
+type MergeLog struct {
+	ID         uint                   `json:"id" gorm:"primarykey"`
+	Kind       string                 `json:"kind" gorm:"not null;index" enums:"user,pet" example:"user"`
+	SourceID   uint                   `json:"source_id" gorm:"not null" example:"12"`
+	TargetID   uint                   `json:"target_id" gorm:"not null;index" example:"7"`
+	MergedByID uint                   `json:"merged_by_id" gorm:"not null"`
+	Snapshot   map[string]interface{} `json:"snapshot" gorm:"serializer:json"`
+	Moved      map[string]int64       `json:"moved" gorm:"serializer:json" example:"pets:2"`
+	CreatedAt  time.Time              `json:"created_at"`
+	MergedBy   User                   `json:"-" gorm:"foreignKey:MergedByID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
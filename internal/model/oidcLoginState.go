@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OIDCLoginState remembers a single sign-on attempt between the redirect to
+// the identity provider and its callback. Only the hash of the state
+// parameter is stored; the nonce and PKCE verifier never leave the server.
+type OIDCLoginState struct {
+	gorm.Model
+	StateHash    string    `gorm:"type:char(64);uniqueIndex;not null"`
+	Nonce        string    `gorm:"not null"`
+	CodeVerifier string    `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	UsedAt       *time.Time
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// PasswordHistory keeps the hashes of a user's previous passwords so they
+// cannot be reused.
+type PasswordHistory struct {
+	ID           uint      `gorm:"primarykey"`
+	UserID       uint      `gorm:"not null;index"`
+	PasswordHash string    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"index"`
+	User         User      `gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
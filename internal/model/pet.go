@@ -1,14 +1,46 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
+const (
+	PetStatusActive      string = "active"
+	PetStatusDeceased    string = "deceased"
+	PetStatusTransferred string = "transferred"
+	PetStatusArchived    string = "archived"
+)
+
+// IsValidPetStatus reports whether status is a lifecycle status. A
+// transferred pet has left the clinic's care, e.g. moved to another
+// practice; a transfer to another user of the clinic keeps it active.
+func IsValidPetStatus(status string) bool {
+	switch status {
+	case PetStatusActive, PetStatusDeceased, PetStatusTransferred, PetStatusArchived:
+		return true
+	}
+	return false
+}
+
 type Pet struct {
 	gorm.Model
-	Name           string `json:"name"`
-	Species        string `json:"species"`
-	Breed          string `json:"breed"`
-	OwnerID        uint   `json:"owner_id"`
-	MedicalHistory string `json:"medical_history"`
+	Name                  string            `json:"name"`
+	Species               string            `json:"species"`
+	Breed                 string            `json:"breed"`
+	OwnerID               uint              `json:"owner_id"`
+	HouseholdID           *uint             `json:"household_id" gorm:"index"`
+	MedicalHistory        string            `json:"medical_history"`
+	Status                string            `json:"status" gorm:"not null;default:active;index" enums:"active,deceased,transferred,archived" example:"active"`
+	StatusChangedAt       *time.Time        `json:"status_changed_at"`
+	StatusNote            string            `json:"status_note,omitempty" example:"Moved abroad"`
+	DeceasedOn            *time.Time        `json:"deceased_on"`
+	Microchip             *string           `json:"microchip" gorm:"uniqueIndex:idx_pets_microchip,where:deleted_at IS NULL" example:"985112345678901"`
+	MicrochipRegisteredAt *time.Time        `json:"microchip_registered_at"`
+	PhotoUpdatedAt        *time.Time        `json:"-"`
+	SearchVector          string            `json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(breed, '')), 'B') || setweight(to_tsvector('simple', coalesce(medical_history, '')), 'C')) STORED;->:false;<-:false;index:idx_pets_search,type:gin"`
+	PhotoURLs             map[string]string `json:"photo_urls,omitempty" gorm:"-"`
+	Alerts                []PetAlert        `json:"alerts,omitempty" gorm:"foreignKey:PetID"`
+	ActiveMedications     []Prescription    `json:"active_medications,omitempty" gorm:"-"`
 }
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	PetAlertKindAllergy          string = "allergy"
+	PetAlertKindChronicCondition string = "chronic_condition"
+	PetAlertKindBehavior         string = "behavior"
+)
+
+const (
+	PetAlertSeverityLow    string = "low"
+	PetAlertSeverityMedium string = "medium"
+	PetAlertSeverityHigh   string = "high"
+)
+
+func IsValidPetAlertKind(kind string) bool {
+	return kind == PetAlertKindAllergy || kind == PetAlertKindChronicCondition || kind == PetAlertKindBehavior
+}
+
+func IsValidPetAlertSeverity(severity string) bool {
+	return severity == PetAlertSeverityLow || severity == PetAlertSeverityMedium || severity == PetAlertSeverityHigh
+}
+
+// PetAlert flags something staff must know before handling a pet, such as
+// an allergy, a chronic condition or a tendency to bite.
+type PetAlert struct {
+	gorm.Model
+	PetID       uint   `json:"pet_id" gorm:"not null;index"`
+	Kind        string `json:"kind" gorm:"not null" enums:"allergy,chronic_condition,behavior" example:"allergy"`
+	Severity    string `json:"severity" gorm:"not null" enums:"low,medium,high" example:"high"`
+	Description string `json:"description" gorm:"not null" example:"Penicillin"`
+	CreatedByID uint   `json:"created_by_id" gorm:"not null"`
+	Pet         Pet    `json:"-" gorm:"foreignKey:PetID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedBy   User   `json:"-" gorm:"foreignKey:CreatedByID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PetTransferStatusPending   string = "pending"
+	PetTransferStatusAccepted  string = "accepted"
+	PetTransferStatusDeclined  string = "declined"
+	PetTransferStatusCancelled string = "cancelled"
+)
+
+// PetTransfer hands a pet over to another user, e.g. after a rehoming or an
+// adoption. The pet only changes owner once the recipient accepts. A pet has
+// at most one pending transfer.
+//
+// The pet is offered to an email address. ToUserID is filled in by the
+// account that answers, so starting a transfer does not reveal whether the
+// address is registered.
+type PetTransfer struct {
+	gorm.Model
+	PetID         uint       `json:"pet_id" gorm:"not null;index;uniqueIndex:idx_pet_transfers_pending,where:status = 'pending' AND deleted_at IS NULL"`
+	FromOwnerID   uint       `json:"from_owner_id" gorm:"not null;index"`
+	ToEmail       string     `json:"to_email" gorm:"not null;default:''" example:"new.owner@example.com"`
+	ToUserID      *uint      `json:"to_user_id" gorm:"index"`
+	InitiatedByID uint       `json:"initiated_by_id" gorm:"not null"`
+	Note          string     `json:"note" example:"Rehomed to a family friend"`
+	Status        string     `json:"status" gorm:"not null;default:pending" enums:"pending,accepted,declined,cancelled" example:"pending"`
+	RespondedAt   *time.Time `json:"responded_at"`
+	Pet           Pet        `json:"pet" gorm:"foreignKey:PetID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FromOwner     User       `json:"-" gorm:"foreignKey:FromOwnerID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ToUser        User       `json:"-" gorm:"foreignKey:ToUserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
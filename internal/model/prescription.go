@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Prescription is a course of medication for a pet. It is active from
+// StartsAt until EndsAt unless discontinued earlier; each refill extends it
+// by another DurationDays.
+type Prescription struct {
+	gorm.Model
+	PetID            uint         `json:"pet_id" gorm:"not null;index"`
+	AppointmentID    *uint        `json:"appointment_id"`
+	Drug             string       `json:"drug" gorm:"not null" example:"Carprofen"`
+	Dose             string       `json:"dose" gorm:"not null" example:"50 mg"`
+	Frequency        string       `json:"frequency" gorm:"not null" example:"Twice daily with food"`
+	DurationDays     int          `json:"duration_days" gorm:"not null" example:"14"`
+	RefillsRemaining int          `json:"refills_remaining" gorm:"not null;default:0" example:"2"`
+	PrescribedByID   uint         `json:"prescribed_by_id" gorm:"not null"`
+	StartsAt         time.Time    `json:"starts_at" gorm:"not null"`
+	EndsAt           time.Time    `json:"ends_at" gorm:"not null;index"`
+	LastRefilledAt   *time.Time   `json:"last_refilled_at"`
+	DiscontinuedAt   *time.Time   `json:"discontinued_at"`
+	Pet              Pet          `json:"-" gorm:"foreignKey:PetID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Appointment      *Appointment `json:"-" gorm:"foreignKey:AppointmentID; constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	PrescribedBy     User         `json:"-" gorm:"foreignKey:PrescribedByID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
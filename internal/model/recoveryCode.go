@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// RecoveryCode is a one-time code that replaces a TOTP code when the user has
+// lost their authenticator. Only a hash is stored.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,105 @@
+package model
+
+import "time"
+
+const (
+	PermissionPetsReadAll         string = "pets:read_all"
+	PermissionPetsWriteAll        string = "pets:write_all"
+	PermissionOwnersRead          string = "owners:read"
+	PermissionAppointmentsReadAll string = "appointments:read_all"
+	PermissionAppointmentsManage  string = "appointments:manage"
+	PermissionDocumentsUpload     string = "documents:upload"
+	PermissionVaccinationsManage  string = "vaccinations:manage"
+	PermissionVisitNotesRead      string = "visit_notes:read"
+	PermissionVisitNotesWrite     string = "visit_notes:write"
+	PermissionPrescriptionsManage string = "prescriptions:manage"
+	PermissionPetAlertsManage     string = "pet_alerts:manage"
+	PermissionVitalsRecord        string = "vitals:record"
+	PermissionMicrochipsLookup    string = "microchips:lookup"
+	PermissionRecordsMerge        string = "records:merge"
+	PermissionUsersManage         string = "users:manage"
+	PermissionUsersImpersonate    string = "users:impersonate"
+	PermissionRolesManage         string = "roles:manage"
+	PermissionSettingsManage      string = "settings:manage"
+	PermissionAPIKeysManage       string = "api_keys:manage"
+	PermissionAuditLogRead        string = "audit_log:read"
+	PermissionCatalogManage       string = "catalog:manage"
+)
+
+type PermissionInfo struct {
+	Name        string `json:"name" example:"pets:read_all"`
+	Description string `json:"description" example:"View every pet, not only your own"`
+}
+
+// Permissions lists every permission a role can be granted.
+var Permissions = []PermissionInfo{
+	{PermissionPetsReadAll, "View every pet, not only your own"},
+	{PermissionPetsWriteAll, "Edit and delete every pet"},
+	{PermissionOwnersRead, "View owner profiles and contact details"},
+	{PermissionAppointmentsReadAll, "View the clinic's appointment schedule"},
+	{PermissionAppointmentsManage, "Book, move and cancel appointments for any pet"},
+	{PermissionDocumentsUpload, "Upload documents to a pet's record"},
+	{PermissionVaccinationsManage, "Record and correct a pet's vaccinations"},
+	{PermissionVisitNotesRead, "Read full clinical visit notes"},
+	{PermissionVisitNotesWrite, "Write, sign and amend clinical visit notes"},
+	{PermissionPrescriptionsManage, "Prescribe, refill and stop medications"},
+	{PermissionPetAlertsManage, "Flag allergies, chronic conditions and behavior warnings on pets"},
+	{PermissionVitalsRecord, "Record and correct a pet's weight and vitals"},
+	{PermissionMicrochipsLookup, "Find a found animal and its owner's contact details by microchip"},
+	{PermissionRecordsMerge, "Find and merge duplicate owners and pets"},
+	{PermissionUsersManage, "Create, disable and unlock user accounts"},
+	{PermissionUsersImpersonate, "Act as a pet owner to see what they see"},
+	{PermissionRolesManage, "Create roles and edit their permissions"},
+	{PermissionSettingsManage, "Change clinic-wide settings"},
+	{PermissionAPIKeysManage, "Create and revoke API keys for integrations"},
+	{PermissionAuditLogRead, "View the audit log of sensitive actions"},
+	{PermissionCatalogManage, "Add species and breeds and map existing pets onto them"},
+}
+
+func IsValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p.Name == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions are granted to the built-in roles when they are first
+// created. Admin is not listed because it always has every permission.
+var DefaultRolePermissions = map[string][]string{
+	UserTypeAdmin: {},
+	UserTypeStaff: {
+		PermissionPetsReadAll,
+		PermissionPetsWriteAll,
+		PermissionOwnersRead,
+		PermissionAppointmentsReadAll,
+		PermissionAppointmentsManage,
+		PermissionDocumentsUpload,
+		PermissionVaccinationsManage,
+		PermissionVisitNotesRead,
+		PermissionVisitNotesWrite,
+		PermissionPrescriptionsManage,
+		PermissionPetAlertsManage,
+		PermissionVitalsRecord,
+		PermissionMicrochipsLookup,
+		PermissionRecordsMerge,
+		PermissionUsersImpersonate,
+	},
+	UserTypeOwner: {},
+}
+
+type Role struct {
+	Name        string    `json:"name" gorm:"primarykey" example:"receptionist"`
+	Description string    `json:"description" example:"Front desk"`
+	BuiltIn     bool      `json:"built_in" gorm:"not null;default:false"`
+	Permissions []string  `json:"permissions" gorm:"-" example:"appointments:read_all,appointments:manage"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type RolePermission struct {
+	RoleName   string `gorm:"primarykey"`
+	Permission string `gorm:"primarykey"`
+	Role       Role   `gorm:"foreignKey:RoleName;references:Name;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
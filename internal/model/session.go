@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session backs a refresh token. Access tokens carry the session ID in their
+// "sid" claim so that revoking the session also invalidates them.
+type Session struct {
+	gorm.Model
+	UserID            uint       `json:"user_id" gorm:"not null;index"`
+	RefreshTokenHash  string     `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	PreviousTokenHash string     `json:"-" gorm:"type:char(64);index"`
+	UserAgent         string     `json:"user_agent"`
+	IPAddress         string     `json:"ip_address"`
+	LastUsedAt        time.Time  `json:"last_used_at"`
+	ExpiresAt         time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt         *time.Time `json:"revoked_at"`
+	User              User       `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
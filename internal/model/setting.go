@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+const (
+	// SettingRequireTwoFactor makes TOTP mandatory for staff and admin users.
+	SettingRequireTwoFactor string = "require_two_factor"
+	// SettingEmailVerificationBackfilled records that accounts created before
+	// email verification existed have been marked verified. It is not meant
+	// to be changed by admins.
+	SettingEmailVerificationBackfilled string = "email_verification_backfilled"
+	// SettingOwnersReadGranted records that the built-in staff role, if it
+	// already existed, has been granted owners:read. It is not meant to be
+	// changed by admins.
+	SettingOwnersReadGranted string = "owners_read_granted"
+)
+
+// Setting is a runtime setting that admins can change without a redeploy.
+type Setting struct {
+	Key       string `gorm:"primarykey"`
+	Value     string `gorm:"not null"`
+	UpdatedAt time.Time
+}
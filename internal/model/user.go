@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -12,11 +14,19 @@ const (
 
 type User struct {
 	gorm.Model
-	Username string `json:"username" gorm:"unique;not null"`
-	Password string `json:"password" gorm:"not null"`
-	Role     string `json:"role" gorm:"not null"`
-	Name     string `json:"name"`
-	Contact  string `json:"contact"`
-	Email    string `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
-	Pets     []Pet  `json:"pets" gorm:"foreignKey:OwnerID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Username        string     `json:"username" gorm:"unique;not null"`
+	Password        string     `json:"password" gorm:"not null"`
+	Role            string     `json:"role" gorm:"not null"`
+	Name            string     `json:"name"`
+	Contact         string     `json:"contact"`
+	Email           string     `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Disabled        bool       `json:"disabled" gorm:"not null;default:false"`
+	DisabledAt      *time.Time `json:"disabled_at"`
+	TOTPEnabled     bool       `json:"totp_enabled" gorm:"column:totp_enabled;not null;default:false"`
+	TOTPSecret      string     `json:"-" gorm:"column:totp_secret"`
+	TOTPLastStep    int64      `json:"-" gorm:"column:totp_last_step;not null;default:0"`
+	OIDCSubject     *string    `json:"-" gorm:"column:oidc_subject;uniqueIndex"`
+	SearchVector    string     `json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(email, '') || ' ' || coalesce(contact, '')), 'B')) STORED;->:false;<-:false;index:idx_users_search,type:gin"`
+	Pets            []Pet      `json:"pets" gorm:"foreignKey:OwnerID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
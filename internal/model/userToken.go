@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TokenPurposeEmailVerification string = "email_verification"
+	TokenPurposePasswordReset     string = "password_reset"
+	TokenPurposeTwoFactorLogin    string = "two_factor_login"
+)
+
+// UserToken is a single-use, expiring token mailed to a user, e.g. to verify
+// an email address or reset a password. Only its hash is stored.
+type UserToken struct {
+	gorm.Model
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	Purpose   string     `json:"purpose" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	Attempts  int        `json:"-" gorm:"not null;default:0"`
+	User      User       `json:"-" gorm:"foreignKey:UserID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Vaccination struct {
+	gorm.Model
+	PetID            uint       `json:"pet_id" gorm:"not null;index"`
+	Vaccine          string     `json:"vaccine" gorm:"not null" example:"Rabies"`
+	LotNumber        string     `json:"lot_number" example:"RB-2291A"`
+	GivenAt          time.Time  `json:"given_at" gorm:"not null"`
+	AdministeredByID *uint      `json:"administered_by_id"`
+	NextDueAt        *time.Time `json:"next_due_at" gorm:"index"`
+	Pet              Pet        `json:"-" gorm:"foreignKey:PetID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AdministeredBy   *User      `json:"-" gorm:"foreignKey:AdministeredByID; constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
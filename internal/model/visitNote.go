@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Vitals struct {
+	WeightKg           *float64 `json:"weight_kg" example:"28.4"`
+	BodyConditionScore *int     `json:"body_condition_score" example:"5"`
+	TemperatureC       *float64 `json:"temperature_c" example:"38.6"`
+	HeartRateBPM       *int     `json:"heart_rate_bpm" gorm:"column:heart_rate_bpm" example:"96"`
+	RespiratoryRateBPM *int     `json:"respiratory_rate_bpm" gorm:"column:respiratory_rate_bpm" example:"24"`
+}
+
+// VisitNote is the clinical record of an appointment in SOAP form. Once
+// signed it can no longer be edited; corrections are added as addenda.
+type VisitNote struct {
+	gorm.Model
+	AppointmentID uint                `json:"appointment_id" gorm:"not null;uniqueIndex"`
+	AuthorID      uint                `json:"author_id" gorm:"not null"`
+	Subjective    string              `json:"subjective" example:"Owner reports limping on the right hind leg for two days"`
+	Objective     string              `json:"objective" example:"Mild swelling of the right stifle, pain on flexion"`
+	Assessment    string              `json:"assessment" example:"Suspected soft tissue injury"`
+	Plan          string              `json:"plan" example:"Rest for one week, carprofen 2 mg/kg twice daily, recheck in 10 days"`
+	Vitals        Vitals              `json:"vitals" gorm:"embedded"`
+	Diagnoses     []string            `json:"diagnoses" gorm:"serializer:json" example:"Soft tissue injury"`
+	SignedAt      *time.Time          `json:"signed_at"`
+	SignedByID    *uint               `json:"signed_by_id"`
+	SearchVector  string              `json:"-" gorm:"type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(assessment, '') || ' ' || coalesce(diagnoses, '')), 'A') || setweight(to_tsvector('simple', coalesce(subjective, '') || ' ' || coalesce(objective, '') || ' ' || coalesce(plan, '')), 'B')) STORED;->:false;<-:false;index:idx_visit_notes_search,type:gin"`
+	Addenda       []VisitNoteAddendum `json:"addenda" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Appointment   Appointment         `json:"-" gorm:"foreignKey:AppointmentID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Author        User                `json:"-" gorm:"foreignKey:AuthorID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+type VisitNoteAddendum struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	VisitNoteID uint      `json:"visit_note_id" gorm:"not null;index"`
+	AuthorID    uint      `json:"author_id" gorm:"not null"`
+	Text        string    `json:"text" gorm:"not null" example:"Correction: carprofen dose is 2 mg/kg once daily"`
+	CreatedAt   time.Time `json:"created_at"`
+	Author      User      `json:"-" gorm:"foreignKey:AuthorID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VitalsMeasurement is one reading of a pet's vitals. Readings taken during a
+// visit are copied from the visit note when it is signed.
+type VitalsMeasurement struct {
+	gorm.Model
+	PetID        uint       `json:"pet_id" gorm:"not null;index:idx_vitals_pet_measured_at,priority:1"`
+	MeasuredAt   time.Time  `json:"measured_at" gorm:"not null;index:idx_vitals_pet_measured_at,priority:2"`
+	Vitals       Vitals     `json:"vitals" gorm:"embedded"`
+	RecordedByID uint       `json:"recorded_by_id" gorm:"not null"`
+	VisitNoteID  *uint      `json:"visit_note_id" gorm:"uniqueIndex"`
+	Pet          Pet        `json:"-" gorm:"foreignKey:PetID; constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	RecordedBy   User       `json:"-" gorm:"foreignKey:RecordedByID; constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	VisitNote    *VisitNote `json:"-" gorm:"foreignKey:VisitNoteID; constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
@@ -0,0 +1,359 @@
+// Package oidc implements the relying party side of the OpenID Connect
+// authorization code flow with PKCE, as used for staff single sign-on.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrNotConfigured = errors.New("single sign-on is not configured")
+
+// Config describes the client registration at the identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim names the ID token claim that lists the user's groups.
+	GroupsClaim string
+	// RoleMap maps IdP groups to local roles. The first entry whose group
+	// the user belongs to decides the role.
+	RoleMap []RoleMapping
+}
+
+type RoleMapping struct {
+	Group string
+	Role  string
+}
+
+// Claims are the ID token claims the clinic uses.
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Groups            []string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider talks to one identity provider. The discovery document is fetched
+// on first use; signing keys are fetched again when a token names an unknown
+// kid, at most once per keyRefreshInterval.
+type Provider struct {
+	Config     Config
+	HTTPClient *http.Client
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+const keyRefreshInterval = time.Minute
+
+func NewProvider(config Config) *Provider {
+	return &Provider{Config: config, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+var (
+	once     sync.Once
+	provider *Provider
+)
+
+// Get returns the process wide provider configured by the OIDC_* environment
+// variables, or ErrNotConfigured when OIDC_ISSUER is not set.
+//
+// OIDC_ROLE_MAP is a comma separated list of group=role pairs, e.g.
+// "clinic-admins=admin,clinic-staff=staff".
+func Get() (*Provider, error) {
+	once.Do(func() {
+		issuer := os.Getenv("OIDC_ISSUER")
+		if issuer == "" {
+			return
+		}
+		scopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+		if len(scopes) == 0 {
+			scopes = []string{"openid", "profile", "email"}
+		}
+		groupsClaim := os.Getenv("OIDC_GROUPS_CLAIM")
+		if groupsClaim == "" {
+			groupsClaim = "groups"
+		}
+		provider = NewProvider(Config{
+			Issuer:       strings.TrimSuffix(issuer, "/"),
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+			Scopes:       scopes,
+			GroupsClaim:  groupsClaim,
+			RoleMap:      ParseRoleMap(os.Getenv("OIDC_ROLE_MAP")),
+		})
+	})
+	if provider == nil {
+		return nil, ErrNotConfigured
+	}
+	return provider, nil
+}
+
+// Set replaces the process wide provider, e.g. with one pointing at a mock
+// identity provider.
+func Set(p *Provider) {
+	once.Do(func() {})
+	provider = p
+}
+
+// ParseRoleMap parses a "group=role,group=role" list, skipping malformed
+// entries.
+func ParseRoleMap(s string) []RoleMapping {
+	var mappings []RoleMapping
+	for _, entry := range strings.Split(s, ",") {
+		group, role, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || group == "" || role == "" {
+			continue
+		}
+		mappings = append(mappings, RoleMapping{Group: strings.TrimSpace(group), Role: strings.TrimSpace(role)})
+	}
+	return mappings
+}
+
+// MapRole returns the local role for a user in groups.
+func (p *Provider) MapRole(groups []string) (string, bool) {
+	for _, mapping := range p.Config.RoleMap {
+		for _, group := range groups {
+			if group == mapping.Group {
+				return mapping.Role, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CodeChallenge derives the S256 PKCE challenge of a code verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the IdP URL the browser is sent to for login.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.Config.ClientID)
+	query.Set("redirect_uri", p.Config.RedirectURL)
+	query.Set("scope", strings.Join(p.Config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems an authorization code and returns the raw ID token.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Config.RedirectURL},
+		"client_id":     {p.Config.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exchanging authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("exchanging authorization code: %s %s: %s", resp.Status, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", errors.New("exchanging authorization code: token response has no id_token")
+	}
+	return body.IDToken, nil
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of
+// an ID token and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (Claims, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	}
+	token, err := jwt.Parse(rawIDToken, keyFunc,
+		jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}),
+		jwt.WithIssuer(p.Config.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute))
+	if err != nil {
+		return Claims{}, fmt.Errorf("verifying ID token: %w", err)
+	}
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return Claims{}, errors.New("verifying ID token: invalid token")
+	}
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return Claims{}, errors.New("verifying ID token: nonce mismatch")
+	}
+
+	claims := Claims{Groups: stringList(mapClaims[p.Config.GroupsClaim])}
+	claims.Subject, _ = mapClaims["sub"].(string)
+	claims.Email, _ = mapClaims["email"].(string)
+	claims.EmailVerified, _ = mapClaims["email_verified"].(bool)
+	claims.Name, _ = mapClaims["name"].(string)
+	claims.PreferredUsername, _ = mapClaims["preferred_username"].(string)
+	if claims.Subject == "" {
+		return Claims{}, errors.New("verifying ID token: missing sub claim")
+	}
+	return claims, nil
+}
+
+// stringList accepts a claim that is either a JSON array of strings or a
+// single space or comma separated string.
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		return strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+	return nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	var doc discoveryDocument
+	if err := p.getJSON(ctx, p.Config.Issuer+"/.well-known/openid-configuration", &doc); err != nil {
+		return nil, fmt.Errorf("fetching discovery document: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.Config.Issuer {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", doc.Issuer, p.Config.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing endpoints")
+	}
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+func (p *Provider) publicKey(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.keysFetchedAt) > keyRefreshInterval
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, doc.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("fetching signing keys: %w", err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		public, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = public
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,101 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newProvider(t *testing.T) (*oidc.Provider, *oidctest.Server) {
+	t.Helper()
+	server := oidctest.NewServer("pet-clinic", jwt.MapClaims{
+		"sub":                "idp-user-1",
+		"email":              "jane.vet@clinic.test",
+		"email_verified":     true,
+		"name":               "Jane Vet",
+		"preferred_username": "jane.vet",
+		"groups":             []string{"clinic-staff"},
+	})
+	t.Cleanup(server.Close)
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:      server.URL,
+		ClientID:    "pet-clinic",
+		RedirectURL: "http://localhost:8000/sso/oidc/callback",
+		Scopes:      []string{"openid", "profile", "email"},
+		GroupsClaim: "groups",
+		RoleMap:     oidc.ParseRoleMap("clinic-staff=staff"),
+	})
+	return provider, server
+}
+
+func TestCodeFlowWithPKCE(t *testing.T) {
+	ctx := context.Background()
+	provider, server := newProvider(t)
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code, state, err := server.Login(authURL)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if state != "state-1" {
+		t.Fatalf("state = %q, want %q", state, "state-1")
+	}
+	rawIDToken, err := provider.Exchange(ctx, code, "verifier-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Subject != "idp-user-1" || claims.Email != "jane.vet@clinic.test" || !claims.EmailVerified {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if role, ok := provider.MapRole(claims.Groups); !ok || role != "staff" {
+		t.Errorf("MapRole(%v) = %q, %v; want staff", claims.Groups, role, ok)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	ctx := context.Background()
+	provider, server := newProvider(t)
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code, _, err := server.Login(authURL)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := provider.Exchange(ctx, code, "another-verifier"); err == nil {
+		t.Fatal("Exchange succeeded with the wrong PKCE verifier")
+	}
+}
+
+func TestVerifyIDTokenRejectsWrongNonce(t *testing.T) {
+	ctx := context.Background()
+	provider, server := newProvider(t)
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code, _, err := server.Login(authURL)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	rawIDToken, err := provider.Exchange(ctx, code, "verifier-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if _, err := provider.VerifyIDToken(ctx, rawIDToken, "nonce-2"); err == nil {
+		t.Fatal("VerifyIDToken accepted a token issued for another nonce")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	_ "github.com/MSaiAswin/pet-clinic-management-system/cmd/api/docs"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/handlers"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
 	"github.com/gorilla/mux"
 	corsHandler "github.com/gorilla/handlers"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -16,18 +17,21 @@ func NewRouter() *mux.Router {
 
 	handlerService := handlers.NewService()
 	router.Use(middleware.RequestLogger)
+	router.Use(middleware.ClientInfo)
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count, X-Next-Cursor, Link")
 			next.ServeHTTP(w, r)
 		})
 	})
 	cors := corsHandler.CORS(
 		corsHandler.AllowedOrigins([]string{"*"}),
 		corsHandler.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-		corsHandler.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+		corsHandler.AllowedHeaders([]string{"Content-Type", "Authorization", "X-API-Key"}),
+		corsHandler.ExposedHeaders([]string{"X-Total-Count", "X-Next-Cursor", "Link"}),
 		corsHandler.AllowCredentials(),
 		corsHandler.MaxAge(3600),
 	)
@@ -39,42 +43,225 @@ func NewRouter() *mux.Router {
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
 	))
 
+	router.HandleFunc("/.well-known/jwks.json", handlerService.JWKSHandler).Methods("GET", "OPTIONS")
 	router.HandleFunc("/signup", handlerService.SignupHandler).Methods("POST", "OPTIONS")
 	router.HandleFunc("/login", handlerService.LoginHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/login/2fa", handlerService.TwoFactorLoginHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/login/2fa/enroll", handlerService.TwoFactorLoginEnrollHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/token/refresh", handlerService.RefreshTokenHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/verify-email", handlerService.VerifyEmailHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/verify-email/resend", handlerService.ResendVerificationEmailHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/password/forgot", handlerService.ForgotPasswordHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/password/reset", handlerService.ResetPasswordHandler).Methods("POST", "OPTIONS")
+	router.HandleFunc("/sso/oidc/login", handlerService.SSOLoginHandler).Methods("GET", "OPTIONS")
+	router.HandleFunc("/sso/oidc/callback", handlerService.SSOCallbackHandler).Methods("GET", "OPTIONS")
 
 	protectedRouter := router.PathPrefix("/").Subrouter()
 	protectedRouter.Use(middleware.ValidateJWT)
 
+	// API keys only reach routes whose permission their scopes can restrict.
+	sessionRouter := protectedRouter.NewRoute().Subrouter()
+	sessionRouter.Use(middleware.ForbidAPIKey)
+
+	sessionRouter.Handle("/logout", middleware.ForbidImpersonation(http.HandlerFunc(handlerService.LogoutHandler))).Methods("POST", "OPTIONS")
+	protectedRouter.Handle("/impersonations", middleware.RequirePermission(model.PermissionUsersImpersonate)(http.HandlerFunc(handlerService.StartImpersonationHandler))).Methods("POST", "OPTIONS")
+	sessionRouter.HandleFunc("/impersonations/end", handlerService.EndImpersonationHandler).Methods("POST", "OPTIONS")
+
 	adminRouter := protectedRouter.PathPrefix("/admin").Subrouter()
-	adminRouter.Use(middleware.ProtectAdminRoute)
+	adminRouter.Use(middleware.ForbidImpersonation)
+
+	usersAdminRouter := adminRouter.NewRoute().Subrouter()
+	usersAdminRouter.Use(middleware.RequirePermission(model.PermissionUsersManage))
+
+	usersAdminRouter.HandleFunc("/users", handlerService.ListUsersHandler).Methods("GET", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users", handlerService.CreateUserHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}", handlerService.AdminGetUserHandler).Methods("GET", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}/disable", handlerService.DisableUserHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}/enable", handlerService.EnableUserHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}/role", handlerService.ChangeUserRoleHandler).Methods("PUT", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}/unlock", handlerService.UnlockUserHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/ips/{ip}/unlock", handlerService.UnlockIPHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/users/{id}/2fa/reset", handlerService.ResetTwoFactorHandler).Methods("POST", "OPTIONS")
+	usersAdminRouter.HandleFunc("/impersonations", handlerService.ListImpersonationsHandler).Methods("GET", "OPTIONS")
+	usersAdminRouter.HandleFunc("/impersonations/{id}", handlerService.GetImpersonationHandler).Methods("GET", "OPTIONS")
+
+	settingsAdminRouter := adminRouter.NewRoute().Subrouter()
+	settingsAdminRouter.Use(middleware.RequirePermission(model.PermissionSettingsManage))
+
+	settingsAdminRouter.HandleFunc("/settings/2fa", handlerService.GetTwoFactorSettingsHandler).Methods("GET", "OPTIONS")
+	settingsAdminRouter.HandleFunc("/settings/2fa", handlerService.UpdateTwoFactorSettingsHandler).Methods("PUT", "OPTIONS")
+
+	rolesAdminRouter := adminRouter.NewRoute().Subrouter()
+	rolesAdminRouter.Use(middleware.RequirePermission(model.PermissionRolesManage))
+
+	rolesAdminRouter.HandleFunc("/permissions", handlerService.ListPermissionsHandler).Methods("GET", "OPTIONS")
+	rolesAdminRouter.HandleFunc("/roles", handlerService.ListRolesHandler).Methods("GET", "OPTIONS")
+	rolesAdminRouter.HandleFunc("/roles", handlerService.CreateRoleHandler).Methods("POST", "OPTIONS")
+	rolesAdminRouter.HandleFunc("/roles/{name}", handlerService.GetRoleHandler).Methods("GET", "OPTIONS")
+	rolesAdminRouter.HandleFunc("/roles/{name}", handlerService.UpdateRoleHandler).Methods("PUT", "OPTIONS")
+	rolesAdminRouter.HandleFunc("/roles/{name}", handlerService.DeleteRoleHandler).Methods("DELETE", "OPTIONS")
+
+	apiKeysAdminRouter := adminRouter.NewRoute().Subrouter()
+	apiKeysAdminRouter.Use(middleware.RequirePermission(model.PermissionAPIKeysManage))
+
+	apiKeysAdminRouter.HandleFunc("/api-keys", handlerService.ListAPIKeysHandler).Methods("GET", "OPTIONS")
+	apiKeysAdminRouter.HandleFunc("/api-keys", handlerService.CreateAPIKeyHandler).Methods("POST", "OPTIONS")
+	apiKeysAdminRouter.HandleFunc("/api-keys/{id}", handlerService.RevokeAPIKeyHandler).Methods("DELETE", "OPTIONS")
+
+	auditAdminRouter := adminRouter.NewRoute().Subrouter()
+	auditAdminRouter.Use(middleware.RequirePermission(model.PermissionAuditLogRead))
+
+	auditAdminRouter.HandleFunc("/audit-events", handlerService.ListAuditEventsHandler).Methods("GET", "OPTIONS")
+
+	catalogAdminRouter := adminRouter.NewRoute().Subrouter()
+	catalogAdminRouter.Use(middleware.RequirePermission(model.PermissionCatalogManage))
+
+	catalogAdminRouter.HandleFunc("/catalog/species", handlerService.AddSpeciesHandler).Methods("POST", "OPTIONS")
+	catalogAdminRouter.HandleFunc("/catalog/species/{id}", handlerService.UpdateSpeciesHandler).Methods("PUT", "OPTIONS")
+	catalogAdminRouter.HandleFunc("/catalog/species/{id}/breeds", handlerService.AddBreedHandler).Methods("POST", "OPTIONS")
+	catalogAdminRouter.HandleFunc("/catalog/species/{id}/breeds/{breedID}", handlerService.UpdateBreedHandler).Methods("PUT", "OPTIONS")
+	catalogAdminRouter.HandleFunc("/catalog/unmapped", handlerService.ListUnmappedPetsHandler).Methods("GET", "OPTIONS")
+	catalogAdminRouter.HandleFunc("/catalog/remap", handlerService.RemapPetsHandler).Methods("POST", "OPTIONS")
 
 	staffRouter := protectedRouter.PathPrefix("/staff").Subrouter()
-	staffRouter.Use(middleware.ProtectStaffRoute)
 
+	staffPetsRouter := staffRouter.NewRoute().Subrouter()
+	staffPetsRouter.Use(middleware.RequirePermission(model.PermissionPetsReadAll))
+
+	staffDocumentsRouter := staffRouter.NewRoute().Subrouter()
+	staffDocumentsRouter.Use(middleware.RequirePermission(model.PermissionDocumentsUpload))
+
+	staffAppointmentsRouter := staffRouter.NewRoute().Subrouter()
+	staffAppointmentsRouter.Use(middleware.RequirePermission(model.PermissionAppointmentsReadAll))
+
+	staffMicrochipsRouter := staffRouter.NewRoute().Subrouter()
+	staffMicrochipsRouter.Use(middleware.RequirePermission(model.PermissionMicrochipsLookup))
+
+	staffMergeRouter := staffRouter.NewRoute().Subrouter()
+	staffMergeRouter.Use(middleware.RequirePermission(model.PermissionRecordsMerge))
+
+	// Recording vaccinations is for staff; owners can still read them below.
+	vaccinationsRouter := protectedRouter.NewRoute().Subrouter()
+	vaccinationsRouter.Use(middleware.RequirePermission(model.PermissionVaccinationsManage))
+
+	// Clinical notes are staff only; owners read the summary below.
+	visitNotesReadRouter := protectedRouter.NewRoute().Subrouter()
+	visitNotesReadRouter.Use(middleware.RequirePermission(model.PermissionVisitNotesRead))
+
+	visitNotesWriteRouter := protectedRouter.NewRoute().Subrouter()
+	visitNotesWriteRouter.Use(middleware.RequirePermission(model.PermissionVisitNotesWrite))
+
+	prescriptionsRouter := protectedRouter.NewRoute().Subrouter()
+	prescriptionsRouter.Use(middleware.RequirePermission(model.PermissionPrescriptionsManage))
+
+	petAlertsReadRouter := protectedRouter.NewRoute().Subrouter()
+	petAlertsReadRouter.Use(middleware.RequirePermission(model.PermissionPetsReadAll))
+
+	petAlertsWriteRouter := protectedRouter.NewRoute().Subrouter()
+	petAlertsWriteRouter.Use(middleware.RequirePermission(model.PermissionPetAlertsManage))
+
+	vitalsRouter := protectedRouter.NewRoute().Subrouter()
+	vitalsRouter.Use(middleware.RequirePermission(model.PermissionVitalsRecord))
+
+	// Every authenticated role may manage its own profile, pets and
+	// appointments; access to other users' records is checked per resource.
 	ownerRouter := protectedRouter.PathPrefix("/").Subrouter()
-	ownerRouter.Use(middleware.ProtectOwnerRoute)
+	ownerRouter.Use(middleware.ForbidAPIKey)
 
 	ownerRouter.HandleFunc("/owners", handlerService.GetUserByIDHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/owners", handlerService.UpdateUserHandler).Methods("PUT", "OPTIONS")
-	ownerRouter.HandleFunc("/owners", handlerService.DeleteUserHandler).Methods("DELETE", "OPTIONS")
+	ownerRouter.Handle("/owners", middleware.ForbidImpersonation(http.HandlerFunc(handlerService.DeleteUserHandler))).Methods("DELETE", "OPTIONS")
+
+	// Account security stays with the owner, even while staff impersonate them.
+	accountRouter := ownerRouter.PathPrefix("/owners").Subrouter()
+	accountRouter.Use(middleware.ForbidImpersonation)
 
-	staffRouter.HandleFunc("/pets", handlerService.GetAllPetsHandler).Methods("GET", "OPTIONS")
-	staffRouter.HandleFunc("/pets/{id}/upload", handlerService.UploadPetDocumentHandler).Methods("POST", "OPTIONS")
+	accountRouter.HandleFunc("/password", handlerService.ChangePasswordHandler).Methods("PUT", "OPTIONS")
+	accountRouter.HandleFunc("/2fa/enroll", handlerService.EnrollTwoFactorHandler).Methods("POST", "OPTIONS")
+	accountRouter.HandleFunc("/2fa/confirm", handlerService.ConfirmTwoFactorHandler).Methods("POST", "OPTIONS")
+	accountRouter.HandleFunc("/2fa/disable", handlerService.DisableTwoFactorHandler).Methods("POST", "OPTIONS")
+	accountRouter.HandleFunc("/2fa/recovery-codes", handlerService.RegenerateRecoveryCodesHandler).Methods("POST", "OPTIONS")
+
+	staffPetsRouter.HandleFunc("/pets", handlerService.GetAllPetsHandler).Methods("GET", "OPTIONS")
+	staffPetsRouter.HandleFunc("/search", handlerService.SearchHandler).Methods("GET", "OPTIONS")
+	staffDocumentsRouter.HandleFunc("/pets/{id}/upload", handlerService.UploadPetDocumentHandler).Methods("POST", "OPTIONS")
 	ownerRouter.HandleFunc("/pets", handlerService.GetPetsByOwnerHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/pets", handlerService.CreatePetHandler).Methods("POST", "OPTIONS")
 	ownerRouter.HandleFunc("/pets/{id}", handlerService.GetPetByIDHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/pets/{id}", handlerService.UpdatePetHandler).Methods("PUT", "OPTIONS")
 	ownerRouter.HandleFunc("/pets/{id}", handlerService.DeletePetHandler).Methods("DELETE", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/status", handlerService.SetPetStatusHandler).Methods("PUT", "OPTIONS")
 	ownerRouter.HandleFunc("/pets/{id}/documents", handlerService.GetPetDocumentsHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/pets/{id}/documents/{docName}", handlerService.GetPetDocumentByNameHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/photo", handlerService.UploadPetPhotoHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/photo", handlerService.DeletePetPhotoHandler).Methods("DELETE", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/photo/{size}", handlerService.GetPetPhotoHandler).Methods("GET", "OPTIONS")
+
+	staffMicrochipsRouter.HandleFunc("/microchips/{number}", handlerService.LookupMicrochipHandler).Methods("GET", "OPTIONS")
 
-	staffRouter.HandleFunc("/appointments/upcoming", handlerService.GetUpcomingAppointmentsHandler).Methods("GET", "OPTIONS")
-	staffRouter.HandleFunc("/appointments/today", handlerService.GetTodayAppointmentsHandler).Methods("GET", "OPTIONS")
+	staffMergeRouter.HandleFunc("/duplicates", handlerService.FindDuplicatesHandler).Methods("GET", "OPTIONS")
+	staffMergeRouter.HandleFunc("/merges", handlerService.ListMergeLogsHandler).Methods("GET", "OPTIONS")
+	staffMergeRouter.HandleFunc("/merges/users", handlerService.MergeUsersHandler).Methods("POST", "OPTIONS")
+	staffMergeRouter.HandleFunc("/merges/pets", handlerService.MergePetsHandler).Methods("POST", "OPTIONS")
+
+	staffPetsRouter.HandleFunc("/vaccinations/due", handlerService.GetDueVaccinationsHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/vaccinations", handlerService.ListVaccinationsHandler).Methods("GET", "OPTIONS")
+	vaccinationsRouter.HandleFunc("/pets/{id}/vaccinations", handlerService.CreateVaccinationHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/vaccinations/{vaccinationID}", handlerService.GetVaccinationHandler).Methods("GET", "OPTIONS")
+	vaccinationsRouter.HandleFunc("/pets/{id}/vaccinations/{vaccinationID}", handlerService.UpdateVaccinationHandler).Methods("PUT", "OPTIONS")
+	vaccinationsRouter.HandleFunc("/pets/{id}/vaccinations/{vaccinationID}", handlerService.DeleteVaccinationHandler).Methods("DELETE", "OPTIONS")
+
+	petAlertsReadRouter.HandleFunc("/pets/{id}/alerts", handlerService.ListPetAlertsHandler).Methods("GET", "OPTIONS")
+	petAlertsWriteRouter.HandleFunc("/pets/{id}/alerts", handlerService.CreatePetAlertHandler).Methods("POST", "OPTIONS")
+	petAlertsWriteRouter.HandleFunc("/pets/{id}/alerts/{alertID}", handlerService.UpdatePetAlertHandler).Methods("PUT", "OPTIONS")
+	petAlertsWriteRouter.HandleFunc("/pets/{id}/alerts/{alertID}", handlerService.DeletePetAlertHandler).Methods("DELETE", "OPTIONS")
+
+	ownerRouter.HandleFunc("/pets/{id}/transfers", handlerService.ListPetTransfersHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/pets/{id}/transfers", handlerService.StartPetTransferHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/transfers", handlerService.ListMyPetTransfersHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/transfers/{id}/accept", handlerService.AcceptPetTransferHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/transfers/{id}/decline", handlerService.DeclinePetTransferHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/transfers/{id}/cancel", handlerService.CancelPetTransferHandler).Methods("POST", "OPTIONS")
+
+	ownerRouter.HandleFunc("/pets/{id}/household", handlerService.SetPetHouseholdHandler).Methods("PUT", "OPTIONS")
+	ownerRouter.HandleFunc("/households", handlerService.ListMyHouseholdsHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/households", handlerService.CreateHouseholdHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}", handlerService.GetHouseholdHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}", handlerService.UpdateHouseholdHandler).Methods("PUT", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}", handlerService.DeleteHouseholdHandler).Methods("DELETE", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}/invites", handlerService.ListHouseholdInvitesHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}/invites", handlerService.InviteHouseholdMemberHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}/invites/{inviteID}/cancel", handlerService.CancelHouseholdInviteHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/household-invites", handlerService.ListMyHouseholdInvitesHandler).Methods("GET", "OPTIONS")
+	ownerRouter.HandleFunc("/household-invites/{id}/accept", handlerService.AcceptHouseholdInviteHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/household-invites/{id}/decline", handlerService.DeclineHouseholdInviteHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}/members/{memberID}", handlerService.UpdateHouseholdMemberHandler).Methods("PUT", "OPTIONS")
+	ownerRouter.HandleFunc("/households/{id}/members/{memberID}", handlerService.RemoveHouseholdMemberHandler).Methods("DELETE", "OPTIONS")
+
+	ownerRouter.HandleFunc("/pets/{id}/vitals", handlerService.GetVitalsSeriesHandler).Methods("GET", "OPTIONS")
+	vitalsRouter.HandleFunc("/pets/{id}/vitals", handlerService.RecordVitalsHandler).Methods("POST", "OPTIONS")
+	vitalsRouter.HandleFunc("/pets/{id}/vitals/{measurementID}", handlerService.DeleteVitalsHandler).Methods("DELETE", "OPTIONS")
+
+	ownerRouter.HandleFunc("/pets/{id}/prescriptions", handlerService.ListPrescriptionsHandler).Methods("GET", "OPTIONS")
+	prescriptionsRouter.HandleFunc("/pets/{id}/prescriptions", handlerService.CreatePrescriptionHandler).Methods("POST", "OPTIONS")
+	prescriptionsRouter.HandleFunc("/pets/{id}/prescriptions/{prescriptionID}/refill", handlerService.RefillPrescriptionHandler).Methods("POST", "OPTIONS")
+	prescriptionsRouter.HandleFunc("/pets/{id}/prescriptions/{prescriptionID}/discontinue", handlerService.DiscontinuePrescriptionHandler).Methods("POST", "OPTIONS")
+
+	staffAppointmentsRouter.HandleFunc("/appointments/upcoming", handlerService.GetUpcomingAppointmentsHandler).Methods("GET", "OPTIONS")
+	staffAppointmentsRouter.HandleFunc("/appointments/today", handlerService.GetTodayAppointmentsHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/appointments", handlerService.GetUpcomingAppointmentsByOwnerHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/appointments", handlerService.CreateAppointmentHandler).Methods("POST", "OPTIONS")
 	ownerRouter.HandleFunc("/appointments/{id}", handlerService.GetAppointmentByIDHandler).Methods("GET", "OPTIONS")
 	ownerRouter.HandleFunc("/appointments/{id}", handlerService.UpdateAppointmentHandler).Methods("PUT", "OPTIONS")
 	ownerRouter.HandleFunc("/appointments/{id}", handlerService.DeleteAppointmentHandler).Methods("DELETE", "OPTIONS")
 
+	visitNotesReadRouter.HandleFunc("/appointments/{id}/visit-note", handlerService.GetVisitNoteHandler).Methods("GET", "OPTIONS")
+	visitNotesWriteRouter.HandleFunc("/appointments/{id}/visit-note", handlerService.SaveVisitNoteHandler).Methods("PUT", "OPTIONS")
+	visitNotesWriteRouter.HandleFunc("/appointments/{id}/visit-note/sign", handlerService.SignVisitNoteHandler).Methods("POST", "OPTIONS")
+	visitNotesWriteRouter.HandleFunc("/appointments/{id}/visit-note/addenda", handlerService.AddVisitNoteAddendumHandler).Methods("POST", "OPTIONS")
+	ownerRouter.HandleFunc("/appointments/{id}/visit-summary", handlerService.GetVisitSummaryHandler).Methods("GET", "OPTIONS")
+
+	ownerRouter.HandleFunc("/catalog/species", handlerService.ListSpeciesHandler).Methods("GET", "OPTIONS")
+
 	return router
 }
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/lockout"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRole = errors.New("invalid role")
+var ErrCannotModifySelf = errors.New("you cannot disable or change the role of your own account")
+var ErrRoleNotGrantable = errors.New("you cannot grant or change a role with permissions you do not hold")
+var ErrInvalidIP = errors.New("invalid IP address")
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type UserListParams struct {
+	Query    string
+	Role     string
+	Disabled *bool
+	Page     int
+	PageSize int
+}
+
+type UserPage struct {
+	Users    []model.User `json:"users"`
+	Page     int          `json:"page" example:"1"`
+	PageSize int          `json:"page_size" example:"20"`
+	Total    int64        `json:"total" example:"42"`
+}
+
+type AdminCreateUserParams struct {
+	Username string `json:"username" example:"jane.vet"`
+	Password string `json:"password" example:"s3cure-Passw0rd"`
+	Name     string `json:"name" example:"Jane Doe"`
+	Email    string `json:"email" example:"jane@clinic.com"`
+	Contact  string `json:"contact" example:"9876543210"`
+	Role     string `json:"role" example:"staff"`
+}
+
+// ListUsers returns one page of users matching the search query and filters.
+// The query is matched against username, name, email and contact.
+func (userService *UserService) ListUsers(params UserListParams, ctx context.Context) (UserPage, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListUsers Service")
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = defaultPageSize
+	}
+	if params.PageSize > maxPageSize {
+		params.PageSize = maxPageSize
+	}
+
+	query := initializers.DB.Model(&model.User{})
+	if q := strings.TrimSpace(params.Query); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("username ILIKE ? OR name ILIKE ? OR email ILIKE ? OR contact ILIKE ?", like, like, like, like)
+	}
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+	if params.Disabled != nil {
+		query = query.Where("disabled = ?", *params.Disabled)
+	}
+
+	page := UserPage{Page: params.Page, PageSize: params.PageSize, Users: []model.User{}}
+	if err := query.Count(&page.Total).Error; err != nil {
+		return UserPage{}, fmt.Errorf("listing users: %w", err)
+	}
+	tx := query.Order("id ASC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&page.Users)
+	if tx.Error != nil {
+		return UserPage{}, fmt.Errorf("listing users: %w", tx.Error)
+	}
+	for i := range page.Users {
+		page.Users[i].Password = ""
+	}
+	return page, nil
+}
+
+// CreateUser lets an admin create an account with any role. Other users
+// with users:manage may only create accounts with roles whose permissions
+// they hold themselves.
+func (userService *UserService) CreateUser(params *AdminCreateUserParams, ctx context.Context) (model.User, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CreateUser Service")
+	if err := validateRoleExists(params.Role); err != nil {
+		return model.User{}, fmt.Errorf("creating user: %w", err)
+	}
+	if err := ensureCanGrantRole(params.Role, ctx); err != nil {
+		return model.User{}, fmt.Errorf("creating user: %w", err)
+	}
+	// The admin vouches for the address, so the account starts out verified.
+	now := time.Now()
+	user := model.User{
+		Username:        params.Username,
+		Password:        params.Password,
+		Name:            params.Name,
+		Email:           params.Email,
+		Contact:         params.Contact,
+		Role:            params.Role,
+		EmailVerifiedAt: &now,
+	}
+	if err := userService.createUser(&user); err != nil {
+		return model.User{}, fmt.Errorf("creating user: %w", err)
+	}
+	user.Password = ""
+	return user, nil
+}
+
+// SetUserDisabled disables or re-enables an account. Disabling also ends all
+// of the user's sessions.
+func (userService *UserService) SetUserDisabled(id uint, disabled bool, ctx context.Context) (model.User, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SetUserDisabled Service")
+	if currentUserID, _ := ctx.Value(middleware.ContextKeyUserID).(uint); currentUserID == id {
+		return model.User{}, ErrCannotModifySelf
+	}
+	user, err := userService.GetUser(id, ctx)
+	if err != nil {
+		return model.User{}, fmt.Errorf("setting disabled on user %d: %w", id, err)
+	}
+	if err := ensureCanGrantRole(user.Role, ctx); err != nil {
+		return model.User{}, fmt.Errorf("setting disabled on user %d: %w", id, err)
+	}
+
+	user.Disabled = disabled
+	user.DisabledAt = nil
+	if disabled {
+		now := time.Now()
+		user.DisabledAt = &now
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"disabled": user.Disabled, "disabled_at": user.DisabledAt}
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return err
+		}
+		if disabled {
+			return tx.Model(&model.Session{}).
+				Where("user_id = ? AND revoked_at IS NULL", id).
+				Update("revoked_at", time.Now()).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return model.User{}, fmt.Errorf("setting disabled on user %d: %w", id, err)
+	}
+	return user, nil
+}
+
+// ChangeUserRole moves a user to another role. Existing sessions are revoked
+// so that no token keeps carrying the old role. Like CreateUser, callers
+// other than admins must hold every permission of both the old and the new
+// role.
+func (userService *UserService) ChangeUserRole(id uint, role string, ctx context.Context) (model.User, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ChangeUserRole Service")
+	if err := validateRoleExists(role); err != nil {
+		return model.User{}, fmt.Errorf("changing role of user %d: %w", id, err)
+	}
+	if currentUserID, _ := ctx.Value(middleware.ContextKeyUserID).(uint); currentUserID == id {
+		return model.User{}, ErrCannotModifySelf
+	}
+	if err := ensureCanGrantRole(role, ctx); err != nil {
+		return model.User{}, fmt.Errorf("changing role of user %d: %w", id, err)
+	}
+	user, err := userService.GetUser(id, ctx)
+	if err != nil {
+		return model.User{}, fmt.Errorf("changing role of user %d: %w", id, err)
+	}
+	if err := ensureCanGrantRole(user.Role, ctx); err != nil {
+		return model.User{}, fmt.Errorf("changing role of user %d: %w", id, err)
+	}
+
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("role", role).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
+		return model.User{}, fmt.Errorf("changing role of user %d: %w", id, err)
+	}
+	user.Role = role
+	return user, nil
+}
+
+// ensureCanGrantRole returns ErrRoleNotGrantable unless the caller may hand
+// out role: admins may hand out any role, everyone else only roles whose
+// permissions they all hold, which never includes the admin role. The same
+// check guards managing a user who has the role.
+func ensureCanGrantRole(role string, ctx context.Context) error {
+	callerRole, _ := ctx.Value(middleware.ContextKeyRole).(string)
+	if callerRole == model.UserTypeAdmin && middleware.HasPermission(ctx, model.PermissionUsersManage) {
+		return nil
+	}
+	if role == model.UserTypeAdmin {
+		return ErrRoleNotGrantable
+	}
+	var permissions []string
+	tx := initializers.DB.Model(&model.RolePermission{}).Where("role_name = ?", role).Pluck("permission", &permissions)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for _, permission := range permissions {
+		if !middleware.HasPermission(ctx, permission) {
+			return ErrRoleNotGrantable
+		}
+	}
+	return nil
+}
+
+// UnlockUser clears the failed login counter and any lockout of a user.
+func (userService *UserService) UnlockUser(id uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UnlockUser Service")
+	user, err := userService.GetUser(id, ctx)
+	if err != nil {
+		return fmt.Errorf("unlocking user %d: %w", id, err)
+	}
+	if err := ensureCanGrantRole(user.Role, ctx); err != nil {
+		return fmt.Errorf("unlocking user %d: %w", id, err)
+	}
+	if err := lockout.Get().UnlockUser(ctx, user.Username); err != nil {
+		return fmt.Errorf("unlocking user %d: %w", id, err)
+	}
+	return nil
+}
+
+// UnlockIP clears the failed login counter and any lockout of a client IP.
+func (userService *UserService) UnlockIP(ip string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UnlockIP Service")
+	if net.ParseIP(ip) == nil {
+		return ErrInvalidIP
+	}
+	if err := lockout.Get().UnlockIP(ctx, ip); err != nil {
+		return fmt.Errorf("unlocking IP %s: %w", ip, err)
+	}
+	return nil
+}
+
+// BootstrapAdmin creates the first admin account from the ADMIN_USERNAME,
+// ADMIN_PASSWORD, ADMIN_EMAIL and ADMIN_NAME environment variables. It does
+// nothing when an admin already exists or the variables are not set.
+func (userService *UserService) BootstrapAdmin(ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside BootstrapAdmin Service")
+	username := os.Getenv("ADMIN_USERNAME")
+	password := os.Getenv("ADMIN_PASSWORD")
+	if username == "" || password == "" {
+		l.Debug().Msg("ADMIN_USERNAME or ADMIN_PASSWORD not set, skipping admin bootstrap")
+		return nil
+	}
+
+	var admins int64
+	if err := initializers.DB.Model(&model.User{}).Where("role = ?", model.UserTypeAdmin).Count(&admins).Error; err != nil {
+		return fmt.Errorf("bootstrapping admin: %w", err)
+	}
+	if admins > 0 {
+		l.Debug().Msg("Admin account already exists, skipping admin bootstrap")
+		return nil
+	}
+
+	name := os.Getenv("ADMIN_NAME")
+	if name == "" {
+		name = "Administrator"
+	}
+	contact := os.Getenv("ADMIN_CONTACT")
+	if contact == "" {
+		contact = "-"
+	}
+	now := time.Now()
+	user := model.User{
+		Username:        username,
+		Password:        password,
+		Name:            name,
+		Email:           os.Getenv("ADMIN_EMAIL"),
+		Contact:         contact,
+		Role:            model.UserTypeAdmin,
+		EmailVerifiedAt: &now,
+	}
+	if err := userService.createUser(&user); err != nil {
+		return fmt.Errorf("bootstrapping admin: %w", err)
+	}
+	l.Info().Str("username", username).Msg("Bootstrapped initial admin account")
+	return nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/authz"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+const apiKeyPrefix = "pck_"
+
+type APIKeyNotFoundError struct {
+	ID uint
+}
+
+func (e APIKeyNotFoundError) Error() string {
+	return fmt.Sprintf("API key with ID %d not found", e.ID)
+}
+
+var ErrAPIKeyNameRequired = errors.New("API key name is required")
+var ErrAPIKeyExpiryInPast = errors.New("API key expiry must be in the future")
+var ErrAPIKeyScopesRequired = errors.New("API key needs at least one scope")
+var ErrScopeNotHeld = errors.New("you cannot grant a scope you do not hold")
+
+type ScopeNotGrantedError struct {
+	Scope string
+	Role  string
+}
+
+func (e ScopeNotGrantedError) Error() string {
+	return fmt.Sprintf("scope %q is not granted by role %q", e.Scope, e.Role)
+}
+
+type APIKeyParams struct {
+	UserID    uint       `json:"user_id" example:"7"`
+	Name      string     `json:"name" example:"Lobby kiosk"`
+	Scopes    []string   `json:"scopes" example:"appointments:read_all"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+}
+
+// CreatedAPIKey is returned once when a key is created. Key is the only copy
+// of the secret; it cannot be recovered later.
+type CreatedAPIKey struct {
+	APIKey model.APIKey `json:"api_key"`
+	Key    string       `json:"key" example:"pck_3kT9xQ2a_Zt0cN8Vf4yB1mLqR6hWkE2uPj9sD5aXo7gYvC3nT"`
+}
+
+// CreateAPIKey issues a key that acts as params.UserID. Every scope must be
+// a permission the user's role currently grants and that the caller holds,
+// and the caller must be allowed to manage the user. Keys are refused on
+// routes that need no permission, so a key without scopes would be of no use.
+func (apiKeyService *APIKeyService) CreateAPIKey(params APIKeyParams, ctx context.Context) (CreatedAPIKey, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CreateAPIKey Service")
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" {
+		return CreatedAPIKey{}, ErrAPIKeyNameRequired
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return CreatedAPIKey{}, ErrAPIKeyExpiryInPast
+	}
+	if len(params.Scopes) == 0 {
+		return CreatedAPIKey{}, ErrAPIKeyScopesRequired
+	}
+	if err := validatePermissions(params.Scopes); err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	var user model.User
+	if err := initializers.DB.First(&user, params.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return CreatedAPIKey{}, UserNotFoundError{ID: params.UserID}
+		}
+		return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+	}
+	if err := ensureCanGrantRole(user.Role, ctx); err != nil {
+		return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+	}
+	scopes := []string{}
+	for _, scope := range params.Scopes {
+		if !middleware.HasPermission(ctx, scope) {
+			return CreatedAPIKey{}, fmt.Errorf("%w: %s", ErrScopeNotHeld, scope)
+		}
+		granted, err := authz.RoleHasPermission(user.Role, scope)
+		if err != nil {
+			return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+		}
+		if !granted {
+			return CreatedAPIKey{}, ScopeNotGrantedError{Scope: scope, Role: user.Role}
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	prefixPart, err := utils.GenerateOpaqueToken(6)
+	if err != nil {
+		return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+	}
+	secret, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+	}
+	prefix := apiKeyPrefix + prefixPart
+	key := prefix + "_" + secret
+	apiKey := model.APIKey{
+		UserID:    user.ID,
+		Name:      params.Name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: params.ExpiresAt,
+	}
+	if err := initializers.DB.Create(&apiKey).Error; err != nil {
+		return CreatedAPIKey{}, fmt.Errorf("creating API key: %w", err)
+	}
+	return CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// ListAPIKeys returns every API key, newest first, optionally only those of
+// one user. Revoked and expired keys are included.
+func (apiKeyService *APIKeyService) ListAPIKeys(userID uint, ctx context.Context) ([]model.APIKey, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListAPIKeys Service")
+	query := initializers.DB.Order("created_at DESC")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	apiKeys := []model.APIKey{}
+	if err := query.Find(&apiKeys).Error; err != nil {
+		return nil, fmt.Errorf("listing API keys: %w", err)
+	}
+	return apiKeys, nil
+}
+
+func (apiKeyService *APIKeyService) GetAPIKey(id uint, ctx context.Context) (model.APIKey, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetAPIKey Service")
+	var apiKey model.APIKey
+	if err := initializers.DB.First(&apiKey, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.APIKey{}, APIKeyNotFoundError{ID: id}
+		}
+		return model.APIKey{}, fmt.Errorf("getting API key %d: %w", id, err)
+	}
+	return apiKey, nil
+}
+
+// RevokeAPIKey stops a key from working. Revoking an already revoked key is
+// a no-op.
+func (apiKeyService *APIKeyService) RevokeAPIKey(id uint, ctx context.Context) (model.APIKey, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RevokeAPIKey Service")
+	apiKey, err := apiKeyService.GetAPIKey(id, ctx)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	if apiKey.RevokedAt != nil {
+		return apiKey, nil
+	}
+	now := time.Now()
+	if err := initializers.DB.Model(&apiKey).Update("revoked_at", now).Error; err != nil {
+		return model.APIKey{}, fmt.Errorf("revoking API key %d: %w", id, err)
+	}
+	apiKey.RevokedAt = &now
+	return apiKey, nil
+}
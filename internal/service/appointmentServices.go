@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
@@ -34,7 +36,11 @@ func (appointmentService *AppointmentService) GetAppointment(id uint, ctx contex
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside GetAppointment Service")
 	var appointment model.Appointment
-	tx := initializers.DB.Preload("Pet").First(&appointment, id)
+	query := initializers.DB.Preload("Pet")
+	if middleware.HasPermission(ctx, model.PermissionPetsReadAll) {
+		query = withPetAlerts(query, "Pet.Alerts")
+	}
+	tx := query.First(&appointment, id)
 	if err := tx.Error; err != nil {
 		switch err {
 		case gorm.ErrRecordNotFound:
@@ -113,6 +119,9 @@ func (appointmentService *AppointmentService) DeleteAppointment(id uint, ctx con
 	if err != nil {
 		return fmt.Errorf("deleting appointment: %w", err)
 	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(existingAppointment.Pet), model.HouseholdAccessBook, model.PermissionAppointmentsManage, ctx); err != nil {
+		return fmt.Errorf("deleting appointment: %w", err)
+	}
 
 	if tx := initializers.DB.Delete(&existingAppointment); tx.Error != nil {
 		return fmt.Errorf("deleting appointment: %w", tx.Error)
@@ -125,7 +134,7 @@ func (appointmentService *AppointmentService) GetUpcomingAppointments() ([]model
 	l := zerolog.Ctx(context.Background())
 	l.Trace().Msg("Inside GetUpcomingAppointments Service")
 	var appointments []model.Appointment
-	tx := initializers.DB.Where("slot > ?", time.Now()).Preload("Pet").Order("slot ASC").Find(&appointments)
+	tx := withPetAlerts(initializers.DB, "Pet.Alerts").Where("slot > ?", time.Now()).Order("slot ASC").Find(&appointments)
 	if tx.Error != nil {
 		return nil, fmt.Errorf("getting all upcoming appointments: %w", tx.Error)
 	}
@@ -138,7 +147,7 @@ func (appointmentService *AppointmentService) GetTodayAppointments() ([]model.Ap
 	l.Trace().Msg("Inside GetTodayAppointments Service")
 	var appointments []model.Appointment
 	today := time.Now().Truncate(24 * time.Hour)
-	tx := initializers.DB.Where("slot >= ? AND slot < ?", today, today.Add(24*time.Hour)).Preload("Pet").Order("slot ASC").Find(&appointments)
+	tx := withPetAlerts(initializers.DB, "Pet.Alerts").Where("slot >= ? AND slot < ?", today, today.Add(24*time.Hour)).Order("slot ASC").Find(&appointments)
 	if tx.Error != nil {
 		return nil, fmt.Errorf("getting today's appointments: %w", tx.Error)
 	}
@@ -149,11 +158,17 @@ func (appointmentService *AppointmentService) GetUpcomingAppointmentsByOwner(ctx
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside GetUpcomingAppointmentsByOwner Service")
 	var appointments []model.Appointment
-	ownerID, ok := ctx.Value("user_id").(uint)
+	ownerID, ok := ctx.Value(middleware.ContextKeyUserID).(uint)
 	if !ok {
 		return nil, fmt.Errorf("getting upcoming appointments by owner: user_id not found in context")
 	}
-	tx := initializers.DB.Where("slot > ? AND owner_id = ?", time.Now(), ownerID).Preload("Pet").Find(&appointments)
+	// Appointments belong to the pet, so they follow it to a new owner and
+	// are shown to the households it is shared with.
+	tx := initializers.DB.Joins("Pet").
+		Where("appointments.slot > ?", time.Now()).
+		Where("\"Pet\".owner_id = ? OR \"Pet\".household_id IN (?)", ownerID, memberHouseholds(initializers.DB, ownerID)).
+		Order("appointments.slot ASC").
+		Find(&appointments)
 	if tx.Error != nil {
 		return nil, fmt.Errorf("getting upcoming appointments by owner %d: %w", ownerID, tx.Error)
 	}
@@ -164,9 +179,16 @@ func (appointmentService *AppointmentService) ValidateAppointment(appointment *m
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside ValidateAppointment Service")
 	petService := &PetService{}
-	if _, err := petService.GetPet(appointment.PetID, ctx); err != nil {
+	pet, err := petService.GetPet(appointment.PetID, ctx)
+	if err != nil {
 		return fmt.Errorf("adding appointment: %w", err)
 	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(pet), model.HouseholdAccessBook, model.PermissionAppointmentsManage, ctx); err != nil {
+		return fmt.Errorf("validating appointment: %w", err)
+	}
+	if err := ensurePetActive(pet); err != nil {
+		return fmt.Errorf("validating appointment: %w", err)
+	}
 
 	existingAppointment, err := appointmentService.GetAppointmentBySlot(appointment.Slot)
 	if err == nil {
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditEventLimit = 100
+	maxAuditEventLimit     = 500
+)
+
+type AuditEventFilter struct {
+	Action string
+	UserID uint
+	Limit  int
+}
+
+// recordAuditEvent stores an audit event on behalf of the current user,
+// within tx so the audited action fails if it cannot be recorded.
+func recordAuditEvent(tx *gorm.DB, event model.AuditEvent, ctx context.Context) error {
+	event.UserID, _ = ctx.Value(middleware.ContextKeyUserID).(uint)
+	if apiKeyID, ok := ctx.Value(middleware.ContextKeyAPIKeyID).(uint); ok {
+		event.APIKeyID = &apiKeyID
+	}
+	event.ClientIP, _ = ctx.Value(middleware.ContextKeyClientIP).(string)
+	event.UserAgent, _ = ctx.Value(middleware.ContextKeyUserAgent).(string)
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("recording audit event %s: %w", event.Action, err)
+	}
+	return nil
+}
+
+// ListAuditEvents returns the latest audit events, newest first.
+func (auditService *AuditService) ListAuditEvents(filter AuditEventFilter, ctx context.Context) ([]model.AuditEvent, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListAuditEvents Service")
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditEventLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditEventLimit)
+	query := initializers.DB.Order("created_at DESC").Limit(filter.Limit)
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	events := []model.AuditEvent{}
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("listing audit events: %w", err)
+	}
+	return events, nil
+}
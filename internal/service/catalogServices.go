@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type SpeciesNotFoundError struct {
+	ID uint
+}
+
+func (e SpeciesNotFoundError) Error() string {
+	return fmt.Sprintf("species with ID %d not found", e.ID)
+}
+
+type BreedNotFoundError struct {
+	ID uint
+}
+
+func (e BreedNotFoundError) Error() string {
+	return fmt.Sprintf("breed with ID %d not found", e.ID)
+}
+
+// UnknownCatalogEntryError reports a species or breed that is not in the
+// catalog, with the closest entries as suggestions.
+type UnknownCatalogEntryError struct {
+	Field       string
+	Value       string
+	Suggestions []string
+}
+
+func (e UnknownCatalogEntryError) Error() string {
+	msg := fmt.Sprintf("unknown %s %q", e.Field, e.Value)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf("; did you mean %s?", strings.Join(e.Suggestions, ", "))
+	}
+	return msg
+}
+
+var ErrCatalogNameRequired = errors.New("name is required")
+var ErrCatalogEntryExists = errors.New("the catalog already has an entry with this name or alias")
+var ErrRemapSourceRequired = errors.New("from_species is required")
+var ErrRemapBreedRequired = errors.New("breed is required when from_breed is given, and only then")
+
+const maxCatalogSuggestions = 3
+
+type CatalogEntryParams struct {
+	Name    string   `json:"name" example:"Golden Retriever"`
+	Aliases []string `json:"aliases" example:"golden,goldie"`
+}
+
+// UnmappedPetValues is a species and breed combination stored on pets that
+// is not a catalog entry, with the entries it most likely means.
+type UnmappedPetValues struct {
+	Species            string   `json:"species" example:"dgo"`
+	Breed              string   `json:"breed" example:"lab"`
+	Pets               int64    `json:"pets" example:"3"`
+	SpeciesSuggestions []string `json:"species_suggestions" example:"Dog"`
+	BreedSuggestions   []string `json:"breed_suggestions" example:"Labrador Retriever"`
+}
+
+// RemapPetsParams maps pets stored with a species, and optionally a breed,
+// onto catalog entries. When from_breed is empty only the species changes.
+type RemapPetsParams struct {
+	FromSpecies string `json:"from_species" example:"dgo"`
+	FromBreed   string `json:"from_breed,omitempty" example:"lab"`
+	Species     string `json:"species" example:"Dog"`
+	Breed       string `json:"breed,omitempty" example:"Labrador Retriever"`
+	// AddAliases also accepts the old values as aliases from now on.
+	AddAliases bool `json:"add_aliases" example:"false"`
+}
+
+type RemapPetsResult struct {
+	Species string `json:"species" example:"Dog"`
+	Breed   string `json:"breed,omitempty" example:"Labrador Retriever"`
+	Updated int64  `json:"updated" example:"3"`
+}
+
+// SeedCatalog fills an empty catalog with model.DefaultCatalog. A catalog
+// that has entries is left alone, so admin changes survive restarts.
+func (catalogService *CatalogService) SeedCatalog(ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SeedCatalog Service")
+	var count int64
+	if err := initializers.DB.Model(&model.Species{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("seeding catalog: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	// Create fills in IDs, so work on a copy of the defaults.
+	catalog := make([]model.Species, len(model.DefaultCatalog))
+	for i, species := range model.DefaultCatalog {
+		species.Breeds = append([]model.Breed(nil), species.Breeds...)
+		catalog[i] = species
+	}
+	if err := initializers.DB.Create(&catalog).Error; err != nil {
+		return fmt.Errorf("seeding catalog: %w", err)
+	}
+	l.Info().Int("species", len(catalog)).Msg("Seeded species and breed catalog")
+	return nil
+}
+
+// ListSpecies returns the catalog, species and breeds sorted by name.
+func (catalogService *CatalogService) ListSpecies(ctx context.Context) ([]model.Species, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListSpecies Service")
+	species, err := loadCatalog(initializers.DB)
+	if err != nil {
+		return nil, fmt.Errorf("listing species: %w", err)
+	}
+	return species, nil
+}
+
+func (catalogService *CatalogService) AddSpecies(params CatalogEntryParams, ctx context.Context) (model.Species, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AddSpecies Service")
+	species := model.Species{}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		catalog, err := loadCatalog(tx)
+		if err != nil {
+			return err
+		}
+		if err := applyCatalogEntryParams(&species.Name, &species.Aliases, params, speciesKeys(catalog, 0)); err != nil {
+			return err
+		}
+		return tx.Create(&species).Error
+	})
+	if err != nil {
+		return model.Species{}, fmt.Errorf("adding species: %w", err)
+	}
+	return species, nil
+}
+
+// UpdateSpecies renames a species or replaces its aliases. Pets stored with
+// the old name are renamed with it.
+func (catalogService *CatalogService) UpdateSpecies(id uint, params CatalogEntryParams, ctx context.Context) (model.Species, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateSpecies Service")
+	var species model.Species
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		catalog, err := loadCatalog(tx)
+		if err != nil {
+			return err
+		}
+		current := findSpeciesByID(catalog, id)
+		if current == nil {
+			return SpeciesNotFoundError{ID: id}
+		}
+		species = *current
+		oldName := species.Name
+		if strings.TrimSpace(params.Name) == "" {
+			params.Name = oldName
+		}
+		if err := applyCatalogEntryParams(&species.Name, &species.Aliases, params, speciesKeys(catalog, id)); err != nil {
+			return err
+		}
+		if err := tx.Select("name", "aliases").Updates(&species).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Pet{}).Where("species = ?", oldName).Update("species", species.Name).Error
+	})
+	if err != nil {
+		return model.Species{}, fmt.Errorf("updating species %d: %w", id, err)
+	}
+	return species, nil
+}
+
+func (catalogService *CatalogService) AddBreed(speciesID uint, params CatalogEntryParams, ctx context.Context) (model.Breed, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AddBreed Service")
+	breed := model.Breed{SpeciesID: speciesID}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		catalog, err := loadCatalog(tx)
+		if err != nil {
+			return err
+		}
+		species := findSpeciesByID(catalog, speciesID)
+		if species == nil {
+			return SpeciesNotFoundError{ID: speciesID}
+		}
+		if err := applyCatalogEntryParams(&breed.Name, &breed.Aliases, params, breedKeys(species, 0)); err != nil {
+			return err
+		}
+		return tx.Create(&breed).Error
+	})
+	if err != nil {
+		return model.Breed{}, fmt.Errorf("adding breed: %w", err)
+	}
+	return breed, nil
+}
+
+// UpdateBreed renames a breed or replaces its aliases. Pets of the species
+// stored with the old name are renamed with it.
+func (catalogService *CatalogService) UpdateBreed(speciesID, id uint, params CatalogEntryParams, ctx context.Context) (model.Breed, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateBreed Service")
+	var breed model.Breed
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		catalog, err := loadCatalog(tx)
+		if err != nil {
+			return err
+		}
+		species := findSpeciesByID(catalog, speciesID)
+		if species == nil {
+			return SpeciesNotFoundError{ID: speciesID}
+		}
+		found := false
+		for _, b := range species.Breeds {
+			if b.ID == id {
+				breed, found = b, true
+			}
+		}
+		if !found {
+			return BreedNotFoundError{ID: id}
+		}
+		oldName := breed.Name
+		if strings.TrimSpace(params.Name) == "" {
+			params.Name = oldName
+		}
+		if err := applyCatalogEntryParams(&breed.Name, &breed.Aliases, params, breedKeys(species, id)); err != nil {
+			return err
+		}
+		if err := tx.Select("name", "aliases").Updates(&breed).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Pet{}).
+			Where("species = ? AND breed = ?", species.Name, oldName).
+			Update("breed", breed.Name).Error
+	})
+	if err != nil {
+		return model.Breed{}, fmt.Errorf("updating breed %d: %w", id, err)
+	}
+	return breed, nil
+}
+
+// ListUnmappedPets finds the species and breed values on pets that are not
+// catalog entries, e.g. rows saved before the catalog existed, so they can
+// be remapped.
+func (catalogService *CatalogService) ListUnmappedPets(ctx context.Context) ([]UnmappedPetValues, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListUnmappedPets Service")
+	catalog, err := loadCatalog(initializers.DB)
+	if err != nil {
+		return nil, fmt.Errorf("listing unmapped pets: %w", err)
+	}
+	var values []UnmappedPetValues
+	err = initializers.DB.Model(&model.Pet{}).
+		Select("species, breed, COUNT(*) AS pets").
+		Group("species, breed").
+		Order("pets DESC, species ASC, breed ASC").
+		Scan(&values).Error
+	if err != nil {
+		return nil, fmt.Errorf("listing unmapped pets: %w", err)
+	}
+
+	unmapped := []UnmappedPetValues{}
+	for _, v := range values {
+		species, breed, err := resolveCatalogEntry(catalog, v.Species, v.Breed)
+		if err == nil && species == v.Species && breed == v.Breed {
+			continue
+		}
+		v.SpeciesSuggestions, v.BreedSuggestions = []string{}, []string{}
+		if match := resolveSpecies(catalog, v.Species); match != nil {
+			v.SpeciesSuggestions = []string{match.Name}
+			if name, ok := resolveBreed(match, v.Breed); ok {
+				v.BreedSuggestions = []string{name}
+			} else {
+				v.BreedSuggestions = suggestCatalogNames(v.Breed, breedKeys(match, 0))
+			}
+		} else {
+			v.SpeciesSuggestions = suggestCatalogNames(v.Species, speciesKeys(catalog, 0))
+		}
+		unmapped = append(unmapped, v)
+	}
+	return unmapped, nil
+}
+
+// RemapPets moves pets stored with a species and breed onto catalog entries.
+func (catalogService *CatalogService) RemapPets(params RemapPetsParams, ctx context.Context) (RemapPetsResult, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RemapPets Service")
+	if params.FromSpecies == "" {
+		return RemapPetsResult{}, ErrRemapSourceRequired
+	}
+	if (params.FromBreed == "") != (params.Breed == "") {
+		return RemapPetsResult{}, ErrRemapBreedRequired
+	}
+	var result RemapPetsResult
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		catalog, err := loadCatalog(tx)
+		if err != nil {
+			return err
+		}
+		species := resolveSpecies(catalog, params.Species)
+		if species == nil {
+			return UnknownCatalogEntryError{Field: "species", Value: params.Species, Suggestions: suggestCatalogNames(params.Species, speciesKeys(catalog, 0))}
+		}
+		result.Species = species.Name
+		updates := map[string]interface{}{"species": species.Name}
+		query := tx.Model(&model.Pet{}).Where("species = ?", params.FromSpecies)
+		var breed *model.Breed
+		if params.FromBreed != "" {
+			name, ok := resolveBreed(species, params.Breed)
+			if !ok {
+				return UnknownCatalogEntryError{Field: "breed", Value: params.Breed, Suggestions: suggestCatalogNames(params.Breed, breedKeys(species, 0))}
+			}
+			for i := range species.Breeds {
+				if species.Breeds[i].Name == name {
+					breed = &species.Breeds[i]
+				}
+			}
+			result.Breed = name
+			updates["breed"] = name
+			query = query.Where("breed = ?", params.FromBreed)
+		}
+		update := query.Updates(updates)
+		if update.Error != nil {
+			return update.Error
+		}
+		result.Updated = update.RowsAffected
+		if !params.AddAliases {
+			return nil
+		}
+		if err := addCatalogAlias(tx, species, &species.Aliases, params.FromSpecies, speciesKeys(catalog, 0)); err != nil {
+			return err
+		}
+		if breed != nil {
+			return addCatalogAlias(tx, breed, &breed.Aliases, params.FromBreed, breedKeys(species, 0))
+		}
+		return nil
+	})
+	if err != nil {
+		return RemapPetsResult{}, fmt.Errorf("remapping pets: %w", err)
+	}
+	l.Info().Str("fromSpecies", params.FromSpecies).Str("fromBreed", params.FromBreed).
+		Str("species", result.Species).Str("breed", result.Breed).Int64("updated", result.Updated).
+		Msg("Remapped pets onto the catalog")
+	return result, nil
+}
+
+// normalizePetCatalogFields replaces a pet's species and breed with the
+// catalog entries they name, or fails with suggestions.
+func normalizePetCatalogFields(db *gorm.DB, pet *model.Pet) error {
+	catalog, err := loadCatalog(db)
+	if err != nil {
+		return err
+	}
+	pet.Species, pet.Breed, err = resolveCatalogEntry(catalog, pet.Species, pet.Breed)
+	return err
+}
+
+func loadCatalog(db *gorm.DB) ([]model.Species, error) {
+	var species []model.Species
+	err := db.Preload("Breeds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name ASC")
+	}).Order("name ASC").Find(&species).Error
+	return species, err
+}
+
+func findSpeciesByID(catalog []model.Species, id uint) *model.Species {
+	for i := range catalog {
+		if catalog[i].ID == id {
+			return &catalog[i]
+		}
+	}
+	return nil
+}
+
+func resolveCatalogEntry(catalog []model.Species, speciesName, breedName string) (string, string, error) {
+	species := resolveSpecies(catalog, speciesName)
+	if species == nil {
+		return "", "", UnknownCatalogEntryError{Field: "species", Value: speciesName, Suggestions: suggestCatalogNames(speciesName, speciesKeys(catalog, 0))}
+	}
+	breed, ok := resolveBreed(species, breedName)
+	if !ok {
+		return "", "", UnknownCatalogEntryError{Field: "breed", Value: breedName, Suggestions: suggestCatalogNames(breedName, breedKeys(species, 0))}
+	}
+	return species.Name, breed, nil
+}
+
+func resolveSpecies(catalog []model.Species, name string) *model.Species {
+	key := catalogKey(name)
+	for i := range catalog {
+		if catalogKey(catalog[i].Name) == key {
+			return &catalog[i]
+		}
+		for _, alias := range catalog[i].Aliases {
+			if catalogKey(alias) == key {
+				return &catalog[i]
+			}
+		}
+	}
+	return nil
+}
+
+func resolveBreed(species *model.Species, name string) (string, bool) {
+	canonical, ok := breedKeys(species, 0)[catalogKey(name)]
+	return canonical, ok
+}
+
+// speciesKeys maps the normalized names and aliases of every species except
+// the one with ID skip, if any, to their names.
+func speciesKeys(catalog []model.Species, skip uint) map[string]string {
+	keys := map[string]string{}
+	for _, species := range catalog {
+		if skip != 0 && species.ID == skip {
+			continue
+		}
+		keys[catalogKey(species.Name)] = species.Name
+		for _, alias := range species.Aliases {
+			keys[catalogKey(alias)] = species.Name
+		}
+	}
+	return keys
+}
+
+// breedKeys maps the normalized names and aliases of the breeds of a species
+// except the one with ID skip, if any, to their names.
+func breedKeys(species *model.Species, skip uint) map[string]string {
+	keys := map[string]string{}
+	for _, breed := range species.Breeds {
+		if skip != 0 && breed.ID == skip {
+			continue
+		}
+		keys[catalogKey(breed.Name)] = breed.Name
+		for _, alias := range breed.Aliases {
+			keys[catalogKey(alias)] = breed.Name
+		}
+	}
+	return keys
+}
+
+// applyCatalogEntryParams validates a name and its aliases against the keys
+// already taken and sets them.
+func applyCatalogEntryParams(name *string, aliases *[]string, params CatalogEntryParams, taken map[string]string) error {
+	entryName := strings.Join(strings.Fields(params.Name), " ")
+	if entryName == "" {
+		return ErrCatalogNameRequired
+	}
+	seen := map[string]bool{catalogKey(entryName): true}
+	entryAliases := []string{}
+	for _, alias := range params.Aliases {
+		key := catalogKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		entryAliases = append(entryAliases, key)
+	}
+	for key := range seen {
+		if _, ok := taken[key]; ok {
+			return ErrCatalogEntryExists
+		}
+	}
+	*name = entryName
+	*aliases = entryAliases
+	return nil
+}
+
+// addCatalogAlias accepts value as another spelling of a catalog entry
+// unless it already names an entry.
+func addCatalogAlias(tx *gorm.DB, entry interface{}, aliases *[]string, value string, taken map[string]string) error {
+	key := catalogKey(value)
+	if _, ok := taken[key]; ok || key == "" {
+		return nil
+	}
+	*aliases = append(*aliases, key)
+	return tx.Model(entry).Select("aliases").Updates(entry).Error
+}
+
+// catalogKey normalizes a name for comparison: case, surrounding and
+// repeated spaces, dashes and underscores do not matter.
+func catalogKey(name string) string {
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(name))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// suggestCatalogNames returns the names whose normalized name or alias is
+// closest to value, allowing one typo plus one per six letters, or that
+// contain it.
+func suggestCatalogNames(value string, keys map[string]string) []string {
+	key := catalogKey(value)
+	if key == "" {
+		return []string{}
+	}
+	maxDistance := 1 + len([]rune(key))/6
+	best := map[string]int{}
+	for candidate, name := range keys {
+		distance := editDistance(key, candidate)
+		if distance > maxDistance {
+			if len(key) < 3 || !strings.Contains(candidate, key) {
+				continue
+			}
+			distance = maxDistance + 1
+		}
+		if d, ok := best[name]; !ok || distance < d {
+			best[name] = distance
+		}
+	}
+	names := make([]string, 0, len(best))
+	for name := range best {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if best[names[i]] != best[names[j]] {
+			return best[names[i]] < best[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > maxCatalogSuggestions {
+		names = names[:maxCatalogSuggestions]
+	}
+	return names
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and swaps of adjacent
+// letters that turn one into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultDuplicateLimit = 50
+	maxDuplicateLimit     = 200
+	// Blocks larger than this, e.g. every "Unknown" named pet of a species,
+	// are too generic to say anything and are skipped.
+	maxDuplicateBlock = 50
+	minUserDuplicate  = 0.4
+	minPetDuplicate   = 0.6
+)
+
+type DuplicateUser struct {
+	ID        uint      `json:"id" example:"7"`
+	Username  string    `json:"username" example:"jane.doe"`
+	Name      string    `json:"name" example:"Jane Doe"`
+	Email     string    `json:"email" example:"jane@example.com"`
+	Contact   string    `json:"contact" example:"9876543210"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DuplicatePet struct {
+	ID           uint    `json:"id" example:"3"`
+	Name         string  `json:"name" example:"Buddy"`
+	Species      string  `json:"species" example:"Dog"`
+	Breed        string  `json:"breed" example:"Golden Retriever"`
+	OwnerID      uint    `json:"owner_id" example:"7"`
+	Microchip    *string `json:"microchip" example:"985112345678901"`
+	OwnerContact string  `json:"-"`
+}
+
+// UserDuplicate is a pair of owner accounts that likely belong to the same
+// person. Score is between 0 and 1; Reasons says what matched.
+type UserDuplicate struct {
+	Users   [2]DuplicateUser `json:"users"`
+	Score   float64          `json:"score" example:"0.9"`
+	Reasons []string         `json:"reasons" example:"same phone number,similar name"`
+}
+
+type PetDuplicate struct {
+	Pets    [2]DuplicatePet `json:"pets"`
+	Score   float64         `json:"score" example:"0.8"`
+	Reasons []string        `json:"reasons" example:"same owner,same name"`
+}
+
+type DuplicateReport struct {
+	Users []UserDuplicate `json:"users"`
+	Pets  []PetDuplicate  `json:"pets"`
+}
+
+// FindDuplicates flags owner accounts and pets that are likely the same.
+// Owners are compared on phone number, email address and name, pets of the
+// same species on name, breed and owner. Only records that share a phone
+// number, email, name or the start of a name are compared, so the check
+// stays cheap on a large clinic. At most limit pairs of each kind are
+// returned, best match first.
+func (duplicateService *DuplicateService) FindDuplicates(limit int, ctx context.Context) (DuplicateReport, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside FindDuplicates Service")
+	if limit < 1 {
+		limit = defaultDuplicateLimit
+	}
+	if limit > maxDuplicateLimit {
+		limit = maxDuplicateLimit
+	}
+
+	var users []DuplicateUser
+	tx := initializers.DB.Model(&model.User{}).
+		Select("id, username, name, email, contact, created_at").
+		Where("role = ?", model.UserTypeOwner).
+		Order("id ASC").
+		Scan(&users)
+	if tx.Error != nil {
+		return DuplicateReport{}, fmt.Errorf("finding duplicates: %w", tx.Error)
+	}
+	var pets []DuplicatePet
+	tx = initializers.DB.Model(&model.Pet{}).
+		Select("pets.id, pets.name, pets.species, pets.breed, pets.owner_id, pets.microchip, owners.contact AS owner_contact").
+		Joins("LEFT JOIN users AS owners ON owners.id = pets.owner_id").
+		Order("pets.id ASC").
+		Scan(&pets)
+	if tx.Error != nil {
+		return DuplicateReport{}, fmt.Errorf("finding duplicates: %w", tx.Error)
+	}
+
+	report := DuplicateReport{Users: findUserDuplicates(users), Pets: []PetDuplicate{}}
+	duplicateOwners := map[[2]uint]bool{}
+	for _, d := range report.Users {
+		duplicateOwners[[2]uint{d.Users[0].ID, d.Users[1].ID}] = true
+	}
+	report.Pets = findPetDuplicates(pets, duplicateOwners)
+	if len(report.Users) > limit {
+		report.Users = report.Users[:limit]
+	}
+	if len(report.Pets) > limit {
+		report.Pets = report.Pets[:limit]
+	}
+	return report, nil
+}
+
+func findUserDuplicates(users []DuplicateUser) []UserDuplicate {
+	blocks := map[string][]int{}
+	for i, u := range users {
+		if phone := phoneKey(u.Contact); phone != "" {
+			blocks["phone:"+phone] = append(blocks["phone:"+phone], i)
+		}
+		if email := emailKey(u.Email); email != "" {
+			blocks["email:"+email] = append(blocks["email:"+email], i)
+		}
+		if name := personNameKey(u.Name); name != "" {
+			blocks["name:"+namePrefix(name, 3)] = append(blocks["name:"+namePrefix(name, 3)], i)
+		}
+	}
+
+	duplicates := []UserDuplicate{}
+	forEachBlockPair(blocks, func(i, j int) {
+		a, b := users[i], users[j]
+		var score float64
+		var reasons []string
+		if phone := phoneKey(a.Contact); phone != "" && phone == phoneKey(b.Contact) {
+			score += 0.5
+			reasons = append(reasons, "same phone number")
+		}
+		if email := emailKey(a.Email); email != "" && email == emailKey(b.Email) {
+			score += 0.3
+			reasons = append(reasons, "similar email address")
+		}
+		score, reasons = scoreNames(personNameKey(a.Name), personNameKey(b.Name), score, reasons)
+		if score >= minUserDuplicate {
+			duplicates = append(duplicates, UserDuplicate{Users: [2]DuplicateUser{a, b}, Score: min(score, 1), Reasons: reasons})
+		}
+	})
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Score != duplicates[j].Score {
+			return duplicates[i].Score > duplicates[j].Score
+		}
+		return duplicates[i].Users[0].ID < duplicates[j].Users[0].ID
+	})
+	return duplicates
+}
+
+// findPetDuplicates compares pets of the same species. A shared name alone
+// is common, so the pets also have to share an owner, or owners that share
+// a phone number or look like duplicates themselves.
+func findPetDuplicates(pets []DuplicatePet, duplicateOwners map[[2]uint]bool) []PetDuplicate {
+	blocks := map[string][]int{}
+	for i, p := range pets {
+		if name := personNameKey(p.Name); name != "" {
+			key := catalogKey(p.Species) + ":" + namePrefix(name, 2)
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	duplicates := []PetDuplicate{}
+	forEachBlockPair(blocks, func(i, j int) {
+		a, b := pets[i], pets[j]
+		if a.Microchip != nil && b.Microchip != nil && *a.Microchip != *b.Microchip {
+			return
+		}
+		var score float64
+		var reasons []string
+		switch {
+		case a.OwnerID == b.OwnerID:
+			score += 0.4
+			reasons = append(reasons, "same owner")
+		case duplicateOwners[[2]uint{min(a.OwnerID, b.OwnerID), max(a.OwnerID, b.OwnerID)}]:
+			score += 0.3
+			reasons = append(reasons, "owners look like duplicates")
+		case phoneKey(a.OwnerContact) != "" && phoneKey(a.OwnerContact) == phoneKey(b.OwnerContact):
+			score += 0.3
+			reasons = append(reasons, "owners share a phone number")
+		default:
+			return
+		}
+		score, reasons = scoreNames(personNameKey(a.Name), personNameKey(b.Name), score, reasons)
+		if catalogKey(a.Breed) == catalogKey(b.Breed) {
+			score += 0.1
+			reasons = append(reasons, "same breed")
+		}
+		if score >= minPetDuplicate {
+			duplicates = append(duplicates, PetDuplicate{Pets: [2]DuplicatePet{a, b}, Score: min(score, 1), Reasons: reasons})
+		}
+	})
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Score != duplicates[j].Score {
+			return duplicates[i].Score > duplicates[j].Score
+		}
+		return duplicates[i].Pets[0].ID < duplicates[j].Pets[0].ID
+	})
+	return duplicates
+}
+
+// forEachBlockPair calls compare once for every pair of indexes that share
+// a block, lower index first.
+func forEachBlockPair(blocks map[string][]int, compare func(i, j int)) {
+	seen := map[[2]int]bool{}
+	for _, block := range blocks {
+		if len(block) < 2 || len(block) > maxDuplicateBlock {
+			continue
+		}
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				pair := [2]int{min(block[x], block[y]), max(block[x], block[y])}
+				if pair[0] == pair[1] || seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				compare(pair[0], pair[1])
+			}
+		}
+	}
+}
+
+func scoreNames(a, b string, score float64, reasons []string) (float64, []string) {
+	if a == "" || b == "" {
+		return score, reasons
+	}
+	if a == b {
+		return score + 0.4, append(reasons, "same name")
+	}
+	if editDistance(a, b) <= 1+len([]rune(a))/6 {
+		return score + 0.25, append(reasons, "similar name")
+	}
+	return score, reasons
+}
+
+// phoneKey keeps the last ten digits of a phone number, so that country
+// codes and formatting do not matter. Numbers too short to be real are
+// ignored.
+func phoneKey(contact string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, contact)
+	if len(digits) < 7 {
+		return ""
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+// emailKey is the mailbox part of an email address without dots and +tags,
+// which most providers ignore.
+func emailKey(email string) string {
+	local, _, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok {
+		return ""
+	}
+	local, _, _ = strings.Cut(local, "+")
+	local = strings.ReplaceAll(local, ".", "")
+	if len(local) < 3 {
+		return ""
+	}
+	return local
+}
+
+// personNameKey lowercases a name, drops punctuation and sorts its words, so
+// that "Doe, Jane" and "jane doe" compare equal.
+func personNameKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func namePrefix(name string, n int) string {
+	runes := []rune(name)
+	if len(runes) > n {
+		runes = runes[:n]
+	}
+	return string(runes)
+}
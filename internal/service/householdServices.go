@@ -0,0 +1,585 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/mailer"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type HouseholdNotFoundError struct {
+	ID uint
+}
+
+func (e HouseholdNotFoundError) Error() string {
+	return fmt.Sprintf("household with ID %d not found", e.ID)
+}
+
+type HouseholdInviteNotFoundError struct {
+	ID uint
+}
+
+func (e HouseholdInviteNotFoundError) Error() string {
+	return fmt.Sprintf("household invite with ID %d not found", e.ID)
+}
+
+type HouseholdMemberNotFoundError struct {
+	ID uint
+}
+
+func (e HouseholdMemberNotFoundError) Error() string {
+	return fmt.Sprintf("household member with ID %d not found", e.ID)
+}
+
+var ErrHouseholdNameRequired = errors.New("household name is required")
+var ErrInvalidHouseholdAccess = errors.New("access must be view, book or manage")
+var ErrHouseholdInvitePending = errors.New("this email address already has a pending invite to the household")
+var ErrHouseholdInviteNotPending = errors.New("the invite is no longer pending")
+var ErrAlreadyHouseholdMember = errors.New("the user is already a member of this household")
+var ErrLastHouseholdManager = errors.New("a household needs at least one member with manage access")
+var ErrOwnerNotHouseholdMember = errors.New("the pet's owner is not a member of this household")
+
+const householdInvitePendingIndex = "idx_household_invites_pending"
+
+type HouseholdParams struct {
+	Name string `json:"name" example:"The Smiths"`
+}
+
+type HouseholdMemberParams struct {
+	Email  string `json:"email,omitempty" example:"partner@example.com"`
+	Access string `json:"access" enums:"view,book,manage" example:"book"`
+}
+
+type PetHouseholdParams struct {
+	// HouseholdID is the household to share the pet with, or null to stop
+	// sharing it.
+	HouseholdID *uint `json:"household_id" example:"1"`
+}
+
+// memberHouseholds selects the IDs of the households a user belongs to, for
+// use as a subquery.
+func memberHouseholds(db *gorm.DB, userID interface{}) *gorm.DB {
+	return db.Model(&model.HouseholdMember{}).Select("household_id").Where("user_id = ?", userID)
+}
+
+// withHouseholdMembers preloads the members of households together with
+// their names and emails, oldest member first.
+func withHouseholdMembers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Select("household_members.*, users.name, users.email").
+			Joins("JOIN users ON users.id = household_members.user_id").
+			Order("household_members.created_at ASC")
+	})
+}
+
+// CreateHousehold creates a household with the current user as its first
+// member, with manage access.
+func (householdService *HouseholdService) CreateHousehold(params HouseholdParams, ctx context.Context) (model.Household, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CreateHousehold Service")
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return model.Household{}, ErrHouseholdNameRequired
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	household := model.Household{Name: name}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&household).Error; err != nil {
+			return err
+		}
+		member := model.HouseholdMember{HouseholdID: household.ID, UserID: userID, Access: model.HouseholdAccessManage}
+		return tx.Create(&member).Error
+	})
+	if err != nil {
+		return model.Household{}, fmt.Errorf("creating household: %w", err)
+	}
+	return householdService.getHousehold(household.ID, model.HouseholdAccessView, ctx)
+}
+
+// ListMyHouseholds returns the households the current user belongs to.
+func (householdService *HouseholdService) ListMyHouseholds(ctx context.Context) ([]model.Household, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListMyHouseholds Service")
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	households := []model.Household{}
+	tx := withHouseholdMembers(initializers.DB).
+		Where("id IN (?)", memberHouseholds(initializers.DB, userID)).
+		Order("name ASC").
+		Find(&households)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing households of user %d: %w", userID, tx.Error)
+	}
+	return households, nil
+}
+
+func (householdService *HouseholdService) GetHousehold(id uint, ctx context.Context) (model.Household, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetHousehold Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessView, ctx)
+	if err != nil {
+		return model.Household{}, fmt.Errorf("getting household %d: %w", id, err)
+	}
+	return household, nil
+}
+
+func (householdService *HouseholdService) UpdateHousehold(id uint, params HouseholdParams, ctx context.Context) (model.Household, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateHousehold Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx)
+	if err != nil {
+		return model.Household{}, fmt.Errorf("updating household %d: %w", id, err)
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return model.Household{}, ErrHouseholdNameRequired
+	}
+	if err := initializers.DB.Model(&household).Update("name", name).Error; err != nil {
+		return model.Household{}, fmt.Errorf("updating household %d: %w", id, err)
+	}
+	household.Name = name
+	return household, nil
+}
+
+// DeleteHousehold dissolves a household. Its pets stay with their owners and
+// are no longer shared, and its pending invites are cancelled.
+func (householdService *HouseholdService) DeleteHousehold(id uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeleteHousehold Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx)
+	if err != nil {
+		return fmt.Errorf("deleting household %d: %w", id, err)
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Pet{}).Where("household_id = ?", id).Update("household_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("household_id = ?", id).Delete(&model.HouseholdMember{}).Error; err != nil {
+			return err
+		}
+		if err := cancelHouseholdInvites(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&household).Error
+	})
+	if err != nil {
+		return fmt.Errorf("deleting household %d: %w", id, err)
+	}
+	return nil
+}
+
+// InviteHouseholdMember invites whoever has the account with the given email
+// to a household and lets them know by email. The invite is stored and
+// returned the same way whether or not such an account exists; the invitee
+// becomes a member, and the household sees their name, only once they
+// accept.
+func (householdService *HouseholdService) InviteHouseholdMember(id uint, params HouseholdMemberParams, ctx context.Context) (model.HouseholdInvite, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside InviteHouseholdMember Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx)
+	if err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("inviting to household %d: %w", id, err)
+	}
+	if !model.IsValidHouseholdAccess(params.Access) {
+		return model.HouseholdInvite{}, ErrInvalidHouseholdAccess
+	}
+	email, err := validators.NormalizeEmail(params.Email)
+	if err != nil {
+		return model.HouseholdInvite{}, err
+	}
+	for _, member := range household.Members {
+		if strings.EqualFold(member.Email, email) {
+			return model.HouseholdInvite{}, ErrAlreadyHouseholdMember
+		}
+	}
+
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	invite := model.HouseholdInvite{
+		HouseholdID:   id,
+		Email:         email,
+		Access:        params.Access,
+		InvitedByID:   userID,
+		Status:        model.HouseholdInviteStatusPending,
+		HouseholdName: household.Name,
+	}
+	if err := initializers.DB.Create(&invite).Error; err != nil {
+		if utils.IsUniqueViolation(err, householdInvitePendingIndex) {
+			return model.HouseholdInvite{}, ErrHouseholdInvitePending
+		}
+		return model.HouseholdInvite{}, fmt.Errorf("inviting to household %d: %w", id, err)
+	}
+
+	var user model.User
+	tx := initializers.DB.Where("LOWER(email) = LOWER(?) AND disabled = ?", email, false).First(&user)
+	if err := tx.Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			l.Error().Err(err).Uint("householdID", id).Msg("Failed to look up a household invitee")
+		}
+		return invite, nil
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("You have been invited to %s", household.Name),
+		Body: fmt.Sprintf("Hello %s,\n\nYou have been invited to the household %s at the clinic with %s access to its pets. Log in at %s to accept or decline the invitation.\n",
+			user.Name, household.Name, params.Access, frontendURL()),
+	}
+	if err := mailer.Get().Send(ctx, msg); err != nil {
+		l.Error().Err(err).Uint("householdID", id).Uint("inviteID", invite.ID).Msg("Failed to notify a household invitee")
+	}
+	return invite, nil
+}
+
+// ListHouseholdInvites returns the pending invites of a household, newest
+// first.
+func (householdService *HouseholdService) ListHouseholdInvites(id uint, ctx context.Context) ([]model.HouseholdInvite, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListHouseholdInvites Service")
+	if _, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx); err != nil {
+		return nil, fmt.Errorf("listing invites of household %d: %w", id, err)
+	}
+	invites := []model.HouseholdInvite{}
+	tx := withHouseholdName(initializers.DB).
+		Where("household_invites.household_id = ? AND household_invites.status = ?", id, model.HouseholdInviteStatusPending).
+		Order("household_invites.created_at DESC").
+		Find(&invites)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing invites of household %d: %w", id, tx.Error)
+	}
+	return invites, nil
+}
+
+// CancelHouseholdInvite withdraws a pending invite.
+func (householdService *HouseholdService) CancelHouseholdInvite(id, inviteID uint, ctx context.Context) (model.HouseholdInvite, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CancelHouseholdInvite Service")
+	if _, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx); err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("cancelling household invite %d: %w", inviteID, err)
+	}
+	invite, err := findHouseholdInvite(initializers.DB.Where("household_invites.household_id = ?", id), inviteID)
+	if err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("cancelling household invite %d: %w", inviteID, err)
+	}
+	if err := closeHouseholdInvite(initializers.DB, &invite, model.HouseholdInviteStatusCancelled); err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("cancelling household invite %d: %w", inviteID, err)
+	}
+	return invite, nil
+}
+
+// ListMyHouseholdInvites returns the pending invites addressed to the
+// current user's email, newest first. Until the address is verified there
+// are none, since anyone could have signed up with it.
+func (householdService *HouseholdService) ListMyHouseholdInvites(ctx context.Context) ([]model.HouseholdInvite, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListMyHouseholdInvites Service")
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	invites := []model.HouseholdInvite{}
+	var user model.User
+	if err := initializers.DB.First(&user, userID).Error; err != nil {
+		return nil, fmt.Errorf("listing household invites of user %d: %w", userID, err)
+	}
+	if user.EmailVerifiedAt == nil {
+		return invites, nil
+	}
+	tx := withHouseholdName(initializers.DB).
+		Where("LOWER(household_invites.email) = LOWER(?) AND household_invites.status = ?", user.Email, model.HouseholdInviteStatusPending).
+		Order("household_invites.created_at DESC").
+		Find(&invites)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing household invites of user %d: %w", userID, tx.Error)
+	}
+	return invites, nil
+}
+
+// AcceptHouseholdInvite makes the current user a member of the household
+// with the access the invite offers.
+func (householdService *HouseholdService) AcceptHouseholdInvite(inviteID uint, ctx context.Context) (model.HouseholdMember, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AcceptHouseholdInvite Service")
+	user, invite, err := householdService.ownHouseholdInvite(inviteID, ctx)
+	if err != nil {
+		return model.HouseholdMember{}, fmt.Errorf("accepting household invite %d: %w", inviteID, err)
+	}
+	member := model.HouseholdMember{HouseholdID: invite.HouseholdID, UserID: user.ID, Access: invite.Access}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := closeHouseholdInvite(tx, &invite, model.HouseholdInviteStatusAccepted); err != nil {
+			return err
+		}
+		if err := tx.First(&model.Household{}, invite.HouseholdID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return HouseholdNotFoundError{ID: invite.HouseholdID}
+			}
+			return err
+		}
+		var members int64
+		err := tx.Model(&model.HouseholdMember{}).
+			Where("household_id = ? AND user_id = ?", invite.HouseholdID, user.ID).
+			Count(&members).Error
+		if err != nil {
+			return err
+		}
+		if members > 0 {
+			return ErrAlreadyHouseholdMember
+		}
+		return tx.Create(&member).Error
+	})
+	if err != nil {
+		return model.HouseholdMember{}, fmt.Errorf("accepting household invite %d: %w", inviteID, err)
+	}
+	member.Name = user.Name
+	member.Email = user.Email
+	return member, nil
+}
+
+func (householdService *HouseholdService) DeclineHouseholdInvite(inviteID uint, ctx context.Context) (model.HouseholdInvite, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeclineHouseholdInvite Service")
+	_, invite, err := householdService.ownHouseholdInvite(inviteID, ctx)
+	if err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("declining household invite %d: %w", inviteID, err)
+	}
+	if err := closeHouseholdInvite(initializers.DB, &invite, model.HouseholdInviteStatusDeclined); err != nil {
+		return model.HouseholdInvite{}, fmt.Errorf("declining household invite %d: %w", inviteID, err)
+	}
+	return invite, nil
+}
+
+// ownHouseholdInvite returns the current user and an invite addressed to
+// their email. Invites to anyone else are reported as not found.
+func (householdService *HouseholdService) ownHouseholdInvite(inviteID uint, ctx context.Context) (model.User, model.HouseholdInvite, error) {
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	var user model.User
+	if err := initializers.DB.First(&user, userID).Error; err != nil {
+		return model.User{}, model.HouseholdInvite{}, err
+	}
+	invite, err := findHouseholdInvite(initializers.DB, inviteID)
+	if err != nil {
+		return model.User{}, model.HouseholdInvite{}, err
+	}
+	if !strings.EqualFold(invite.Email, user.Email) {
+		return model.User{}, model.HouseholdInvite{}, HouseholdInviteNotFoundError{ID: inviteID}
+	}
+	if user.EmailVerifiedAt == nil {
+		return model.User{}, model.HouseholdInvite{}, ErrEmailNotVerified
+	}
+	return user, invite, nil
+}
+
+// UpdateHouseholdMember changes a member's access level.
+func (householdService *HouseholdService) UpdateHouseholdMember(id, memberID uint, params HouseholdMemberParams, ctx context.Context) (model.HouseholdMember, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateHouseholdMember Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx)
+	if err != nil {
+		return model.HouseholdMember{}, fmt.Errorf("updating household member %d: %w", memberID, err)
+	}
+	member, err := householdMember(household, memberID)
+	if err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if !model.IsValidHouseholdAccess(params.Access) {
+		return model.HouseholdMember{}, ErrInvalidHouseholdAccess
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&member).Update("access", params.Access).Error; err != nil {
+			return err
+		}
+		return ensureHouseholdManager(tx, id)
+	})
+	if err != nil {
+		return model.HouseholdMember{}, fmt.Errorf("updating household member %d: %w", memberID, err)
+	}
+	member.Access = params.Access
+	return member, nil
+}
+
+// RemoveHouseholdMember removes a member from a household. Members with
+// manage access may remove anyone; every member may leave. Pets the member
+// owns are no longer shared with the household.
+func (householdService *HouseholdService) RemoveHouseholdMember(id, memberID uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RemoveHouseholdMember Service")
+	household, err := householdService.getHousehold(id, model.HouseholdAccessView, ctx)
+	if err != nil {
+		return fmt.Errorf("removing household member %d: %w", memberID, err)
+	}
+	member, err := householdMember(household, memberID)
+	if err != nil {
+		return err
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	if member.UserID != userID {
+		if _, err := householdService.getHousehold(id, model.HouseholdAccessManage, ctx); err != nil {
+			return fmt.Errorf("removing household member %d: %w", memberID, err)
+		}
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&member).Error; err != nil {
+			return err
+		}
+		if err := ensureHouseholdManager(tx, id); err != nil {
+			return err
+		}
+		err := tx.Model(&model.Pet{}).
+			Where("household_id = ? AND owner_id = ?", id, member.UserID).
+			Update("household_id", nil).Error
+		if err != nil {
+			return err
+		}
+		// A household whose last member left has nothing left to share.
+		if len(household.Members) == 1 {
+			if err := cancelHouseholdInvites(tx, id); err != nil {
+				return err
+			}
+			return tx.Delete(&household).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("removing household member %d: %w", memberID, err)
+	}
+	return nil
+}
+
+// SetPetHousehold shares a pet with one of its owner's households, or stops
+// sharing it when householdID is nil. Only the owner and staff with
+// pets:write_all may do so.
+func (householdService *HouseholdService) SetPetHousehold(petID uint, householdID *uint, ctx context.Context) (model.Pet, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SetPetHousehold Service")
+	petService := &PetService{}
+	pet, err := petService.GetPet(petID, ctx)
+	if err != nil {
+		return model.Pet{}, fmt.Errorf("sharing pet %d: %w", petID, err)
+	}
+	if err := validators.ValidateResourceOwner(validators.Owner{UserID: pet.OwnerID}, "", model.PermissionPetsWriteAll, ctx); err != nil {
+		return model.Pet{}, fmt.Errorf("sharing pet %d: %w", petID, err)
+	}
+	if householdID != nil {
+		var household model.Household
+		if err := withHouseholdMembers(initializers.DB).First(&household, *householdID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.Pet{}, HouseholdNotFoundError{ID: *householdID}
+			}
+			return model.Pet{}, fmt.Errorf("sharing pet %d: %w", petID, err)
+		}
+		isMember := false
+		for _, member := range household.Members {
+			isMember = isMember || member.UserID == pet.OwnerID
+		}
+		if !isMember {
+			return model.Pet{}, ErrOwnerNotHouseholdMember
+		}
+	}
+	if err := initializers.DB.Model(&pet).Update("household_id", householdID).Error; err != nil {
+		return model.Pet{}, fmt.Errorf("sharing pet %d: %w", petID, err)
+	}
+	pet.HouseholdID = householdID
+	return pet, nil
+}
+
+// getHousehold returns a household with its members if the current user is
+// a member whose access level covers access. Staff with pets:read_all may
+// view any household and staff with pets:write_all may manage it.
+func (householdService *HouseholdService) getHousehold(id uint, access string, ctx context.Context) (model.Household, error) {
+	var household model.Household
+	if err := withHouseholdMembers(initializers.DB).First(&household, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Household{}, HouseholdNotFoundError{ID: id}
+		}
+		return model.Household{}, err
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	for _, member := range household.Members {
+		if member.UserID == userID && model.HouseholdAccessCovers(member.Access, access) {
+			return household, nil
+		}
+	}
+	permission := model.PermissionPetsWriteAll
+	if access == model.HouseholdAccessView {
+		permission = model.PermissionPetsReadAll
+	}
+	if middleware.HasPermission(ctx, permission) {
+		return household, nil
+	}
+	return model.Household{}, validators.ResourceNotOwnedError{}
+}
+
+// withHouseholdName reads the name of the household of each invite.
+func withHouseholdName(db *gorm.DB) *gorm.DB {
+	return db.Select("household_invites.*, households.name AS household_name").
+		Joins("JOIN households ON households.id = household_invites.household_id AND households.deleted_at IS NULL")
+}
+
+func findHouseholdInvite(db *gorm.DB, id uint) (model.HouseholdInvite, error) {
+	var invite model.HouseholdInvite
+	if err := withHouseholdName(db).First(&invite, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.HouseholdInvite{}, HouseholdInviteNotFoundError{ID: id}
+		}
+		return model.HouseholdInvite{}, err
+	}
+	return invite, nil
+}
+
+// cancelHouseholdInvites withdraws the pending invites of a household that
+// is being dissolved.
+func cancelHouseholdInvites(tx *gorm.DB, householdID uint) error {
+	return tx.Model(&model.HouseholdInvite{}).
+		Where("household_id = ? AND status = ?", householdID, model.HouseholdInviteStatusPending).
+		Updates(map[string]interface{}{"status": model.HouseholdInviteStatusCancelled, "responded_at": time.Now()}).Error
+}
+
+// closeHouseholdInvite moves a pending invite to its final status, failing
+// if it was answered or cancelled in the meantime.
+func closeHouseholdInvite(tx *gorm.DB, invite *model.HouseholdInvite, status string) error {
+	now := time.Now()
+	result := tx.Model(invite).
+		Where("status = ?", model.HouseholdInviteStatusPending).
+		Updates(map[string]interface{}{"status": status, "responded_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrHouseholdInviteNotPending
+	}
+	invite.Status = status
+	invite.RespondedAt = &now
+	return nil
+}
+
+func householdMember(household model.Household, memberID uint) (model.HouseholdMember, error) {
+	for _, member := range household.Members {
+		if member.ID == memberID {
+			return member, nil
+		}
+	}
+	return model.HouseholdMember{}, HouseholdMemberNotFoundError{ID: memberID}
+}
+
+// ensureHouseholdManager fails unless a household that still has members
+// has at least one with manage access, so it cannot be left unmanaged.
+func ensureHouseholdManager(tx *gorm.DB, householdID uint) error {
+	var members, managers int64
+	if err := tx.Model(&model.HouseholdMember{}).Where("household_id = ?", householdID).Count(&members).Error; err != nil {
+		return err
+	}
+	err := tx.Model(&model.HouseholdMember{}).
+		Where("household_id = ? AND access = ?", householdID, model.HouseholdAccessManage).
+		Count(&managers).Error
+	if err != nil {
+		return err
+	}
+	if members > 0 && managers == 0 {
+		return ErrLastHouseholdManager
+	}
+	return nil
+}
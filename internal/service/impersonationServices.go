@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type ImpersonationNotFoundError struct {
+	ID uint
+}
+
+func (e ImpersonationNotFoundError) Error() string {
+	return fmt.Sprintf("impersonation with ID %d not found", e.ID)
+}
+
+var ErrImpersonationReasonRequired = errors.New("a reason for impersonating is required")
+var ErrCanOnlyImpersonateOwners = errors.New("only active owner accounts can be impersonated")
+var ErrAlreadyImpersonating = errors.New("already impersonating a user")
+var ErrNotImpersonating = errors.New("not impersonating a user")
+var ErrImpersonationNeedsSession = errors.New("impersonation requires a login session, not an API key")
+
+var impersonationTTL = durationFromEnv("IMPERSONATION_TTL", 30*time.Minute)
+
+type ImpersonationParams struct {
+	UserID uint   `json:"user_id" example:"42"`
+	Reason string `json:"reason" example:"Owner called about next week's appointment"`
+}
+
+// ImpersonationToken is an access token that acts as the owner. It cannot be
+// refreshed; a new impersonation must be started once it expires.
+type ImpersonationToken struct {
+	Token         string              `json:"token" example:"eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjYtMTAifQ.eyJ..."`
+	ExpiresIn     int64               `json:"expires_in" example:"1800"`
+	Impersonation model.Impersonation `json:"impersonation"`
+}
+
+type ImpersonationListParams struct {
+	ImpersonatorID uint
+	UserID         uint
+	Page           int
+	PageSize       int
+}
+
+type ImpersonationPage struct {
+	Impersonations []model.Impersonation `json:"impersonations"`
+	Page           int                   `json:"page" example:"1"`
+	PageSize       int                   `json:"page_size" example:"20"`
+	Total          int64                 `json:"total" example:"42"`
+}
+
+// StartImpersonation lets the current staff member act as an owner. The
+// token is tied to the staff member's session, so logging out also ends the
+// impersonation.
+func (impersonationService *ImpersonationService) StartImpersonation(params ImpersonationParams, ctx context.Context) (ImpersonationToken, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside StartImpersonation Service")
+	if _, ok := ctx.Value(middleware.ContextKeyImpersonatorID).(uint); ok {
+		return ImpersonationToken{}, ErrAlreadyImpersonating
+	}
+	impersonatorID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	sessionID, _ := ctx.Value(middleware.ContextKeySessionID).(uint)
+	if sessionID == 0 {
+		return ImpersonationToken{}, ErrImpersonationNeedsSession
+	}
+	params.Reason = strings.TrimSpace(params.Reason)
+	if params.Reason == "" {
+		return ImpersonationToken{}, ErrImpersonationReasonRequired
+	}
+
+	var user model.User
+	if err := initializers.DB.First(&user, params.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ImpersonationToken{}, UserNotFoundError{ID: params.UserID}
+		}
+		return ImpersonationToken{}, fmt.Errorf("starting impersonation: %w", err)
+	}
+	if user.Role != model.UserTypeOwner || user.Disabled {
+		return ImpersonationToken{}, ErrCanOnlyImpersonateOwners
+	}
+
+	impersonation := model.Impersonation{
+		ImpersonatorID: impersonatorID,
+		UserID:         user.ID,
+		SessionID:      sessionID,
+		Reason:         params.Reason,
+		ExpiresAt:      time.Now().Add(impersonationTTL),
+	}
+	if err := initializers.DB.Create(&impersonation).Error; err != nil {
+		return ImpersonationToken{}, fmt.Errorf("starting impersonation: %w", err)
+	}
+	token, err := utils.GenerateImpersonationJWT(user.ID, sessionID, impersonation.ID, impersonatorID, user.Username, user.Role, impersonationTTL)
+	if err != nil {
+		return ImpersonationToken{}, fmt.Errorf("starting impersonation: %w", err)
+	}
+	return ImpersonationToken{
+		Token:         token,
+		ExpiresIn:     int64(impersonationTTL.Seconds()),
+		Impersonation: impersonation,
+	}, nil
+}
+
+// EndImpersonation ends the impersonation the current request is made under.
+func (impersonationService *ImpersonationService) EndImpersonation(ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside EndImpersonation Service")
+	impersonationID, ok := ctx.Value(middleware.ContextKeyImpersonationID).(uint)
+	if !ok {
+		return ErrNotImpersonating
+	}
+	tx := initializers.DB.Model(&model.Impersonation{}).
+		Where("id = ? AND ended_at IS NULL", impersonationID).
+		Update("ended_at", time.Now())
+	if tx.Error != nil {
+		return fmt.Errorf("ending impersonation %d: %w", impersonationID, tx.Error)
+	}
+	return nil
+}
+
+// ListImpersonations returns one page of impersonations, newest first.
+func (impersonationService *ImpersonationService) ListImpersonations(params ImpersonationListParams, ctx context.Context) (ImpersonationPage, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListImpersonations Service")
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = defaultPageSize
+	}
+	if params.PageSize > maxPageSize {
+		params.PageSize = maxPageSize
+	}
+	query := initializers.DB.Model(&model.Impersonation{})
+	if params.ImpersonatorID != 0 {
+		query = query.Where("impersonator_id = ?", params.ImpersonatorID)
+	}
+	if params.UserID != 0 {
+		query = query.Where("user_id = ?", params.UserID)
+	}
+	page := ImpersonationPage{Page: params.Page, PageSize: params.PageSize, Impersonations: []model.Impersonation{}}
+	if err := query.Count(&page.Total).Error; err != nil {
+		return ImpersonationPage{}, fmt.Errorf("listing impersonations: %w", err)
+	}
+	tx := query.Order("created_at DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&page.Impersonations)
+	if tx.Error != nil {
+		return ImpersonationPage{}, fmt.Errorf("listing impersonations: %w", tx.Error)
+	}
+	return page, nil
+}
+
+// GetImpersonation returns an impersonation with every request made under it.
+func (impersonationService *ImpersonationService) GetImpersonation(id uint, ctx context.Context) (model.Impersonation, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetImpersonation Service")
+	var impersonation model.Impersonation
+	tx := initializers.DB.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).First(&impersonation, id)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Impersonation{}, ImpersonationNotFoundError{ID: id}
+		}
+		return model.Impersonation{}, fmt.Errorf("getting impersonation %d: %w", id, err)
+	}
+	return impersonation, nil
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrMergeSameRecord = errors.New("a record cannot be merged into itself")
+var ErrMergeNotOwner = errors.New("only owner accounts can be merged")
+var ErrMergeSpeciesMismatch = errors.New("pets of different species cannot be merged")
+var ErrMergeMicrochipConflict = errors.New("the pets have different microchips")
+var ErrMergeDocumentConflict = errors.New("both pets have a document with the same name")
+var ErrMergeIntoArchived = errors.New("an active pet cannot be merged into an archived one; merge the archived pet into it instead")
+
+// MergeParams merges the duplicate SourceID into TargetID, which is kept.
+type MergeParams struct {
+	SourceID uint `json:"source_id" example:"12"`
+	TargetID uint `json:"target_id" example:"7"`
+}
+
+// MergeUsers merges a duplicate owner account into another one in a single
+// transaction. The duplicate's pets, transfers and household memberships
+// move to the target, which also takes over its name and contact if it has
+// none. Appointments and documents belong to the pets and move with them.
+// The duplicate is then signed out and deleted, and the merge is logged.
+func (duplicateService *DuplicateService) MergeUsers(params MergeParams, ctx context.Context) (model.MergeLog, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside MergeUsers Service")
+	if params.SourceID == params.TargetID {
+		return model.MergeLog{}, ErrMergeSameRecord
+	}
+	mergedByID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	mergeLog := model.MergeLog{Kind: model.MergeKindUser, SourceID: params.SourceID, TargetID: params.TargetID, MergedByID: mergedByID, Moved: map[string]int64{}}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var source, target model.User
+		err := inLockOrder(params.SourceID, params.TargetID, func(id uint) error {
+			if id == params.SourceID {
+				return firstUser(tx, id, &source)
+			}
+			return firstUser(tx, id, &target)
+		})
+		if err != nil {
+			return err
+		}
+		if source.Role != model.UserTypeOwner || target.Role != model.UserTypeOwner {
+			return ErrMergeNotOwner
+		}
+		source.Password, source.TOTPSecret = "", ""
+		if mergeLog.Snapshot, err = mergeSnapshot(source); err != nil {
+			return err
+		}
+
+		moved := tx.Model(&model.Pet{}).Where("owner_id = ?", source.ID).Update("owner_id", target.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		mergeLog.Moved["pets"] = moved.RowsAffected
+
+		if mergeLog.Moved["transfers"], err = mergeUserTransfers(tx, source.ID, target.ID); err != nil {
+			return err
+		}
+		if mergeLog.Moved["household_memberships"], err = mergeHouseholdMemberships(tx, source.ID, target.ID); err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if strings.TrimSpace(target.Name) == "" && source.Name != "" {
+			updates["name"] = source.Name
+		}
+		if strings.TrimSpace(target.Contact) == "" && source.Contact != "" {
+			updates["contact"] = source.Contact
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&target).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Model(&model.Session{}).Where("user_id = ? AND revoked_at IS NULL", source.ID).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.APIKey{}).Where("user_id = ? AND revoked_at IS NULL", source.ID).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&source).Updates(map[string]interface{}{"disabled": true, "disabled_at": now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+		return tx.Create(&mergeLog).Error
+	})
+	if err != nil {
+		return model.MergeLog{}, fmt.Errorf("merging user %d into %d: %w", params.SourceID, params.TargetID, err)
+	}
+	l.Info().Uint("sourceID", params.SourceID).Uint("targetID", params.TargetID).Interface("moved", mergeLog.Moved).Msg("Merged duplicate user")
+	return mergeLog, nil
+}
+
+// MergePets merges a duplicate pet into another pet of the same species in
+// a single transaction. The duplicate's appointments, vaccinations,
+// prescriptions, alerts, vitals, transfers and documents move to the target,
+// which also takes over its microchip, household and photo if it has none;
+// medical histories are combined. The duplicate is then deleted and the
+// merge is logged.
+//
+// The target keeps its lifecycle status. When it is deceased, transferred or
+// archived the duplicate's future appointments and pending transfers are
+// cancelled instead of moved. An active pet cannot be merged into an
+// archived one, since that would hide it; the archived pet has to be merged
+// into the active one instead.
+func (duplicateService *DuplicateService) MergePets(params MergeParams, ctx context.Context) (model.MergeLog, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside MergePets Service")
+	if params.SourceID == params.TargetID {
+		return model.MergeLog{}, ErrMergeSameRecord
+	}
+	mergedByID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	mergeLog := model.MergeLog{Kind: model.MergeKindPet, SourceID: params.SourceID, TargetID: params.TargetID, MergedByID: mergedByID, Moved: map[string]int64{}}
+	var undo []func()
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var source, target model.Pet
+		err := inLockOrder(params.SourceID, params.TargetID, func(id uint) error {
+			if id == params.SourceID {
+				return firstPet(tx, id, &source)
+			}
+			return firstPet(tx, id, &target)
+		})
+		if err != nil {
+			return err
+		}
+		if catalogKey(source.Species) != catalogKey(target.Species) {
+			return ErrMergeSpeciesMismatch
+		}
+		if source.Microchip != nil && target.Microchip != nil && *source.Microchip != *target.Microchip {
+			return ErrMergeMicrochipConflict
+		}
+		if mergeLog.Snapshot, err = mergeSnapshot(source); err != nil {
+			return err
+		}
+
+		// The kept pet's status wins. A pet that is no longer active has no
+		// future, so the duplicate's future appointments are cancelled
+		// rather than moved, just as SetPetStatus would have done.
+		if target.Status == model.PetStatusArchived && source.Status == model.PetStatusActive {
+			return ErrMergeIntoArchived
+		}
+		if ensurePetActive(target) != nil {
+			if mergeLog.Moved["cancelled_appointments"], err = cancelPetFuture(tx, source.ID); err != nil {
+				return err
+			}
+		} else {
+			// A pending transfer of the duplicate would compete with the
+			// target's own, so it is cancelled rather than moved.
+			err = tx.Model(&model.PetTransfer{}).
+				Where("pet_id = ? AND status = ?", source.ID, model.PetTransferStatusPending).
+				Updates(map[string]interface{}{"status": model.PetTransferStatusCancelled, "responded_at": time.Now()}).Error
+			if err != nil {
+				return err
+			}
+		}
+		for name, table := range map[string]interface{}{
+			"appointments":  &model.Appointment{},
+			"vaccinations":  &model.Vaccination{},
+			"prescriptions": &model.Prescription{},
+			"alerts":        &model.PetAlert{},
+			"vitals":        &model.VitalsMeasurement{},
+			"transfers":     &model.PetTransfer{},
+		} {
+			moved := tx.Model(table).Where("pet_id = ?", source.ID).Update("pet_id", target.ID)
+			if moved.Error != nil {
+				return moved.Error
+			}
+			mergeLog.Moved[name] = moved.RowsAffected
+		}
+
+		// The duplicate goes first so that its microchip is free for the
+		// target under the unique index.
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+		updates := map[string]interface{}{}
+		if history := strings.TrimSpace(source.MedicalHistory); history != "" && !strings.Contains(target.MedicalHistory, history) {
+			updates["medical_history"] = strings.TrimSpace(target.MedicalHistory + "\n\n" + history)
+		}
+		if target.Microchip == nil && source.Microchip != nil {
+			updates["microchip"] = source.Microchip
+			updates["microchip_registered_at"] = source.MicrochipRegisteredAt
+		}
+		if target.HouseholdID == nil && source.HouseholdID != nil && source.OwnerID == target.OwnerID {
+			updates["household_id"] = source.HouseholdID
+		}
+		movePhoto := target.PhotoUpdatedAt == nil && source.PhotoUpdatedAt != nil
+		if movePhoto {
+			updates["photo_updated_at"] = source.PhotoUpdatedAt
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&target).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		// Files cannot be rolled back with the transaction, so they move
+		// last and are put back below if anything fails, the commit
+		// included.
+		mergeLog.Moved["documents"], err = moveFiles(filepath.Join("uploads", "pets", fmt.Sprint(source.ID)), filepath.Join("uploads", "pets", fmt.Sprint(target.ID)), &undo)
+		if err == nil && movePhoto {
+			_, err = moveFiles(petPhotoDir(source.ID), petPhotoDir(target.ID), &undo)
+		}
+		if err == nil {
+			err = tx.Create(&mergeLog).Error
+		}
+		return err
+	})
+	if err != nil {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		return model.MergeLog{}, fmt.Errorf("merging pet %d into %d: %w", params.SourceID, params.TargetID, err)
+	}
+	l.Info().Uint("sourceID", params.SourceID).Uint("targetID", params.TargetID).Interface("moved", mergeLog.Moved).Msg("Merged duplicate pet")
+	return mergeLog, nil
+}
+
+// ListMergeLogs returns the latest merges, newest first.
+func (duplicateService *DuplicateService) ListMergeLogs(ctx context.Context) ([]model.MergeLog, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListMergeLogs Service")
+	logs := []model.MergeLog{}
+	if err := initializers.DB.Order("created_at DESC, id DESC").Limit(maxDuplicateLimit).Find(&logs).Error; err != nil {
+		return nil, fmt.Errorf("listing merges: %w", err)
+	}
+	return logs, nil
+}
+
+// inLockOrder loads the two records of a merge lower ID first. The rows are
+// locked as they are read, so two merges of the same pair in opposite
+// directions wait for each other instead of both going through.
+func inLockOrder(sourceID, targetID uint, load func(id uint) error) error {
+	first, second := sourceID, targetID
+	if second < first {
+		first, second = second, first
+	}
+	if err := load(first); err != nil {
+		return err
+	}
+	return load(second)
+}
+
+func firstUser(tx *gorm.DB, id uint, user *model.User) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return UserNotFoundError{ID: id}
+		}
+		return err
+	}
+	return nil
+}
+
+func firstPet(tx *gorm.DB, id uint, pet *model.Pet) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(pet, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PetNotFoundError{ID: id}
+		}
+		return err
+	}
+	return nil
+}
+
+// mergeUserTransfers points the transfers of a duplicate user at the target
+// and cancels pending ones that would now go from the target to itself.
+func mergeUserTransfers(tx *gorm.DB, sourceID, targetID uint) (int64, error) {
+	from := tx.Model(&model.PetTransfer{}).Where("from_owner_id = ?", sourceID).Update("from_owner_id", targetID)
+	if from.Error != nil {
+		return 0, from.Error
+	}
+	to := tx.Model(&model.PetTransfer{}).Where("to_user_id = ?", sourceID).Update("to_user_id", targetID)
+	if to.Error != nil {
+		return 0, to.Error
+	}
+	err := tx.Model(&model.PetTransfer{}).
+		Where("from_owner_id = ? AND to_user_id = ? AND status = ?", targetID, targetID, model.PetTransferStatusPending).
+		Updates(map[string]interface{}{"status": model.PetTransferStatusCancelled, "responded_at": time.Now()}).Error
+	return from.RowsAffected + to.RowsAffected, err
+}
+
+// mergeHouseholdMemberships moves a duplicate user's household memberships
+// to the target. Where both are members the target keeps the higher of the
+// two access levels.
+func mergeHouseholdMemberships(tx *gorm.DB, sourceID, targetID uint) (int64, error) {
+	var memberships []model.HouseholdMember
+	if err := tx.Where("user_id IN ?", []uint{sourceID, targetID}).Find(&memberships).Error; err != nil {
+		return 0, err
+	}
+	targetMemberships := map[uint]model.HouseholdMember{}
+	for _, membership := range memberships {
+		if membership.UserID == targetID {
+			targetMemberships[membership.HouseholdID] = membership
+		}
+	}
+	var shared []uint
+	for _, membership := range memberships {
+		kept, ok := targetMemberships[membership.HouseholdID]
+		if membership.UserID != sourceID || !ok {
+			continue
+		}
+		shared = append(shared, membership.HouseholdID)
+		if model.HouseholdAccessCovers(kept.Access, membership.Access) {
+			continue
+		}
+		if err := tx.Model(&kept).Update("access", membership.Access).Error; err != nil {
+			return 0, err
+		}
+	}
+	if len(shared) > 0 {
+		err := tx.Where("user_id = ? AND household_id IN ?", sourceID, shared).Delete(&model.HouseholdMember{}).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	moved := tx.Model(&model.HouseholdMember{}).Where("user_id = ?", sourceID).Update("user_id", targetID)
+	return moved.RowsAffected, moved.Error
+}
+
+// mergeSnapshot keeps a record as it was before the merge.
+func mergeSnapshot(record interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := map[string]interface{}{}
+	return snapshot, json.Unmarshal(data, &snapshot)
+}
+
+// moveFiles moves the files of one directory into another and returns how
+// many it moved. It refuses to overwrite a file and records how to move
+// each file back in undo.
+func moveFiles(from, to string, undo *[]func()) (int64, error) {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if err := os.MkdirAll(to, os.ModePerm); err != nil {
+		return 0, err
+	}
+	var moved int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		src, dst := filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name())
+		if _, err := os.Stat(dst); err == nil {
+			return moved, fmt.Errorf("%w: %s", ErrMergeDocumentConflict, entry.Name())
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return moved, err
+		}
+		*undo = append(*undo, func() { os.Rename(dst, src) })
+		moved++
+	}
+	return moved, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type MicrochipNotFoundError struct {
+	Number string
+}
+
+func (e MicrochipNotFoundError) Error() string {
+	return fmt.Sprintf("no pet is registered with microchip %s", e.Number)
+}
+
+// microchipIndex is the unique index on pets.microchip. It catches two pets
+// saved with the same number at once, which applyMicrochip cannot.
+const microchipIndex = "idx_pets_microchip"
+
+var ErrMicrochipTaken = errors.New("another pet is already registered with this microchip")
+var ErrMicrochipRegisteredInFuture = errors.New("microchip registration date cannot be in the future")
+
+// MicrochipOwner holds the contact details staff need to reunite a found
+// animal with its owner.
+type MicrochipOwner struct {
+	ID      uint   `json:"id" example:"7"`
+	Name    string `json:"name" example:"Jane Doe"`
+	Email   string `json:"email" example:"jane@example.com"`
+	Contact string `json:"contact" example:"+1 555 0100"`
+}
+
+type MicrochipLookup struct {
+	Pet   model.Pet      `json:"pet"`
+	Owner MicrochipOwner `json:"owner"`
+}
+
+// LookupMicrochip finds the pet registered with a microchip and its owner's
+// contact details. Every lookup is recorded in the audit log, including the
+// ones that find nothing and the ones with a malformed number.
+func (microchipService *MicrochipService) LookupMicrochip(number string, ctx context.Context) (MicrochipLookup, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside LookupMicrochip Service")
+	normalized, err := validators.NormalizeMicrochip(number)
+	if err != nil {
+		event := model.AuditEvent{Action: model.AuditActionMicrochipLookupFailed, ResourceType: "pet", Detail: number}
+		if auditErr := recordAuditEvent(initializers.DB, event, ctx); auditErr != nil {
+			return MicrochipLookup{}, fmt.Errorf("looking up microchip: %w", auditErr)
+		}
+		return MicrochipLookup{}, err
+	}
+
+	var pet model.Pet
+	var owner model.User
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		event := model.AuditEvent{Action: model.AuditActionMicrochipLookup, ResourceType: "pet", Detail: normalized}
+		result := withPetAlerts(tx, "Alerts").Where("microchip = ?", normalized).First(&pet)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return result.Error
+		}
+		if result.Error == nil {
+			event.ResourceID = &pet.ID
+			err := tx.First(&owner, pet.OwnerID).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+		return recordAuditEvent(tx, event, ctx)
+	})
+	if err != nil {
+		return MicrochipLookup{}, fmt.Errorf("looking up microchip %s: %w", normalized, err)
+	}
+	if pet.ID == 0 {
+		return MicrochipLookup{}, MicrochipNotFoundError{Number: normalized}
+	}
+	setPetPhotoURLs(&pet)
+	l.Info().Uint("petID", pet.ID).Msg("Microchip looked up")
+	return MicrochipLookup{
+		Pet:   pet,
+		Owner: MicrochipOwner{ID: owner.ID, Name: owner.Name, Email: owner.Email, Contact: owner.Contact},
+	}, nil
+}
+
+// applyMicrochip validates the microchip of a pet being saved. The number
+// must not belong to another pet, and the registration date defaults to now.
+func applyMicrochip(db *gorm.DB, pet *model.Pet) error {
+	if pet.Microchip == nil {
+		return nil
+	}
+	normalized, err := validators.NormalizeMicrochip(*pet.Microchip)
+	if err != nil {
+		return err
+	}
+	pet.Microchip = &normalized
+	var taken int64
+	if err := db.Model(&model.Pet{}).Where("microchip = ? AND id <> ?", normalized, pet.ID).Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return ErrMicrochipTaken
+	}
+	now := time.Now()
+	if pet.MicrochipRegisteredAt == nil {
+		pet.MicrochipRegisteredAt = &now
+	} else if pet.MicrochipRegisteredAt.After(now) {
+		return ErrMicrochipRegisteredInFuture
+	}
+	return nil
+}
+
+// microchipSaveError turns a write refused by the microchip index into
+// ErrMicrochipTaken.
+func microchipSaveError(err error) error {
+	if utils.IsUniqueViolation(err, microchipIndex) {
+		return ErrMicrochipTaken
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrPasswordReused = errors.New("password was used recently, choose a different one")
+
+// ChangePassword replaces the password of a logged in user after checking the
+// current one. All existing sessions are revoked and a new one is started for
+// the caller.
+func (userService *UserService) ChangePassword(userID uint, currentPassword, newPassword string, ctx context.Context) (AuthTokens, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ChangePassword Service")
+	if currentPassword == "" || newPassword == "" {
+		return AuthTokens{}, ErrInvalidUserInput
+	}
+	var user model.User
+	if tx := initializers.DB.First(&user, userID); tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return AuthTokens{}, UserNotFoundError{ID: userID}
+		}
+		return AuthTokens{}, fmt.Errorf("changing password of user %d: %w", userID, tx.Error)
+	}
+	if !utils.CheckPasswordHash(currentPassword, user.Password) {
+		return AuthTokens{}, ErrInvalidCredentials
+	}
+
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		return userService.setPassword(tx, &user, newPassword)
+	})
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("changing password of user %d: %w", userID, err)
+	}
+
+	sessionService := &SessionService{}
+	return sessionService.CreateSession(&user, ctx)
+}
+
+// setPassword applies the password policy, refuses recently used passwords,
+// stores the new hash and revokes every session of the user.
+func (userService *UserService) setPassword(tx *gorm.DB, user *model.User, newPassword string) error {
+	policy := validators.DefaultPasswordPolicy
+	if err := policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	if utils.CheckPasswordHash(newPassword, user.Password) {
+		return ErrPasswordReused
+	}
+	if policy.HistorySize > 0 {
+		var history []model.PasswordHistory
+		if err := tx.Where("user_id = ?", user.ID).
+			Order("created_at DESC").
+			Limit(policy.HistorySize).
+			Find(&history).Error; err != nil {
+			return err
+		}
+		for _, old := range history {
+			if utils.CheckPasswordHash(newPassword, old.PasswordHash) {
+				return ErrPasswordReused
+			}
+		}
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if policy.HistorySize > 0 {
+		if err := tx.Create(&model.PasswordHistory{UserID: user.ID, PasswordHash: user.Password}).Error; err != nil {
+			return err
+		}
+		keep := tx.Model(&model.PasswordHistory{}).
+			Select("id").
+			Where("user_id = ?", user.ID).
+			Order("created_at DESC").
+			Limit(policy.HistorySize)
+		if err := tx.Where("user_id = ? AND id NOT IN (?)", user.ID, keep).
+			Delete(&model.PasswordHistory{}).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(user).Update("password", hashedPassword).Error; err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	return tx.Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", user.ID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type PetAlertNotFoundError struct {
+	ID uint
+}
+
+func (e PetAlertNotFoundError) Error() string {
+	return fmt.Sprintf("pet alert with ID %d not found", e.ID)
+}
+
+var ErrInvalidPetAlertKind = errors.New("kind must be allergy, chronic_condition or behavior")
+var ErrInvalidPetAlertSeverity = errors.New("severity must be low, medium or high")
+var ErrPetAlertDescriptionRequired = errors.New("description is required")
+
+type PetAlertParams struct {
+	Kind        string `json:"kind" enums:"allergy,chronic_condition,behavior" example:"allergy"`
+	Severity    string `json:"severity" enums:"low,medium,high" example:"high"`
+	Description string `json:"description" example:"Penicillin"`
+}
+
+// petAlertOrder lists the most severe alerts first.
+const petAlertOrder = "CASE severity WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, created_at ASC"
+
+// withPetAlerts preloads the alerts of the pets at path, e.g. "Alerts" or
+// "Pet.Alerts", most severe first.
+func withPetAlerts(db *gorm.DB, path string) *gorm.DB {
+	return db.Preload(path, func(db *gorm.DB) *gorm.DB {
+		return db.Order(petAlertOrder)
+	})
+}
+
+func (petAlertService *PetAlertService) ListPetAlerts(petID uint, ctx context.Context) ([]model.PetAlert, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListPetAlerts Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return nil, fmt.Errorf("listing alerts of pet %d: %w", petID, err)
+	}
+	alerts := []model.PetAlert{}
+	if err := initializers.DB.Where("pet_id = ?", petID).Order(petAlertOrder).Find(&alerts).Error; err != nil {
+		return nil, fmt.Errorf("listing alerts of pet %d: %w", petID, err)
+	}
+	return alerts, nil
+}
+
+func (petAlertService *PetAlertService) AddPetAlert(petID uint, params PetAlertParams, ctx context.Context) (model.PetAlert, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AddPetAlert Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.PetAlert{}, fmt.Errorf("adding pet alert: %w", err)
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	alert := model.PetAlert{PetID: petID, CreatedByID: userID}
+	if err := applyPetAlertParams(&alert, params); err != nil {
+		return model.PetAlert{}, err
+	}
+	if err := initializers.DB.Create(&alert).Error; err != nil {
+		return model.PetAlert{}, fmt.Errorf("adding pet alert: %w", err)
+	}
+	return alert, nil
+}
+
+// UpdatePetAlert changes an alert. Fields left empty keep their value.
+func (petAlertService *PetAlertService) UpdatePetAlert(petID, id uint, params PetAlertParams, ctx context.Context) (model.PetAlert, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdatePetAlert Service")
+	alert, err := petAlertService.getPetAlert(petID, id, ctx)
+	if err != nil {
+		return model.PetAlert{}, fmt.Errorf("updating pet alert %d: %w", id, err)
+	}
+	if params.Kind == "" {
+		params.Kind = alert.Kind
+	}
+	if params.Severity == "" {
+		params.Severity = alert.Severity
+	}
+	if strings.TrimSpace(params.Description) == "" {
+		params.Description = alert.Description
+	}
+	if err := applyPetAlertParams(&alert, params); err != nil {
+		return model.PetAlert{}, err
+	}
+	if err := initializers.DB.Save(&alert).Error; err != nil {
+		return model.PetAlert{}, fmt.Errorf("updating pet alert %d: %w", id, err)
+	}
+	return alert, nil
+}
+
+func (petAlertService *PetAlertService) DeletePetAlert(petID, id uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeletePetAlert Service")
+	alert, err := petAlertService.getPetAlert(petID, id, ctx)
+	if err != nil {
+		return fmt.Errorf("deleting pet alert %d: %w", id, err)
+	}
+	if err := initializers.DB.Delete(&alert).Error; err != nil {
+		return fmt.Errorf("deleting pet alert %d: %w", id, err)
+	}
+	return nil
+}
+
+func (petAlertService *PetAlertService) getPetAlert(petID, id uint, ctx context.Context) (model.PetAlert, error) {
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.PetAlert{}, err
+	}
+	var alert model.PetAlert
+	if err := initializers.DB.Where("pet_id = ?", petID).First(&alert, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.PetAlert{}, PetAlertNotFoundError{ID: id}
+		}
+		return model.PetAlert{}, err
+	}
+	return alert, nil
+}
+
+func applyPetAlertParams(alert *model.PetAlert, params PetAlertParams) error {
+	if !model.IsValidPetAlertKind(params.Kind) {
+		return ErrInvalidPetAlertKind
+	}
+	if !model.IsValidPetAlertSeverity(params.Severity) {
+		return ErrInvalidPetAlertSeverity
+	}
+	description := strings.TrimSpace(params.Description)
+	if description == "" {
+		return ErrPetAlertDescriptionRequired
+	}
+	alert.Kind = params.Kind
+	alert.Severity = params.Severity
+	alert.Description = description
+	return nil
+}
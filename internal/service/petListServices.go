@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidPetSort = errors.New("sort must be one of id, name, species, breed, created_at or updated_at, optionally prefixed with -")
+var ErrInvalidPetCursor = errors.New("cursor is not valid for this sort")
+
+// petSortColumns whitelists the fields the staff pet list can be sorted by.
+var petSortColumns = map[string]string{
+	"id":         "pets.id",
+	"name":       "pets.name",
+	"species":    "pets.species",
+	"breed":      "pets.breed",
+	"created_at": "pets.created_at",
+	"updated_at": "pets.updated_at",
+}
+
+// PetListParams filters and pages the staff pet list. Query is matched
+// against the pet's name and its owner's name, email and contact.
+type PetListParams struct {
+	Query           string
+	Species         string
+	Breed           string
+	OwnerName       string
+	Status          string
+	IncludeArchived bool
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	// Sort is a field of petSortColumns, descending when prefixed with "-".
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+type PetPage struct {
+	Pets  []model.Pet
+	Total int64
+	// NextCursor continues the list after this page; it is empty on the
+	// last page.
+	NextCursor string
+}
+
+// petCursor is the position after the last pet of a page: the value of the
+// sort field and the pet ID, which breaks ties.
+type petCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v,omitempty"`
+	ID    uint   `json:"id"`
+}
+
+// ListPets returns one page of pets matching the filters, sorted by a
+// whitelisted field. Pages are addressed by cursor rather than offset, so
+// they stay fast and stable while pets are added. Archived pets are left
+// out unless IncludeArchived is set or Status asks for them.
+func (perService *PetService) ListPets(params PetListParams, ctx context.Context) (PetPage, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListPets Service")
+	if params.Limit < 1 {
+		params.Limit = defaultPageSize
+	}
+	if params.Limit > maxPageSize {
+		params.Limit = maxPageSize
+	}
+	if params.Sort == "" {
+		params.Sort = "id"
+	}
+	field, descending := strings.CutPrefix(params.Sort, "-")
+	column, ok := petSortColumns[field]
+	if !ok {
+		return PetPage{}, ErrInvalidPetSort
+	}
+	if params.Status != "" && !model.IsValidPetStatus(params.Status) {
+		return PetPage{}, ErrInvalidPetStatus
+	}
+
+	query := filterPets(initializers.DB.Model(&model.Pet{}), params)
+	page := PetPage{Pets: []model.Pet{}}
+	if err := query.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return PetPage{}, fmt.Errorf("listing pets: %w", err)
+	}
+
+	direction, after := "ASC", ">"
+	if descending {
+		direction, after = "DESC", "<"
+	}
+	if params.Cursor != "" {
+		cursor, err := decodePetCursor(params.Cursor, params.Sort)
+		if err != nil {
+			return PetPage{}, err
+		}
+		if field == "id" {
+			query = query.Where("pets.id "+after+" ?", cursor.ID)
+		} else {
+			value, err := petCursorValue(field, cursor.Value)
+			if err != nil {
+				return PetPage{}, err
+			}
+			query = query.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND pets.id %[2]s ?))", column, after), value, value, cursor.ID)
+		}
+	}
+	if field != "id" {
+		query = query.Order(column + " " + direction)
+	}
+	tx := withPetAlerts(query, "Alerts").
+		Order("pets.id " + direction).
+		Limit(params.Limit + 1).
+		Find(&page.Pets)
+	if tx.Error != nil {
+		return PetPage{}, fmt.Errorf("listing pets: %w", tx.Error)
+	}
+
+	if len(page.Pets) > params.Limit {
+		page.Pets = page.Pets[:params.Limit]
+		page.NextCursor = encodePetCursor(params.Sort, field, page.Pets[len(page.Pets)-1])
+	}
+	for i := range page.Pets {
+		setPetPhotoURLs(&page.Pets[i])
+	}
+	return page, nil
+}
+
+func filterPets(query *gorm.DB, params PetListParams) *gorm.DB {
+	q := strings.TrimSpace(params.Query)
+	ownerName := strings.TrimSpace(params.OwnerName)
+	if q != "" || ownerName != "" {
+		query = query.Joins("JOIN users AS owners ON owners.id = pets.owner_id")
+	}
+	if q != "" {
+		like := "%" + q + "%"
+		query = query.Where("pets.name ILIKE ? OR owners.name ILIKE ? OR owners.email ILIKE ? OR owners.contact ILIKE ?", like, like, like, like)
+	}
+	if ownerName != "" {
+		query = query.Where("owners.name ILIKE ?", "%"+ownerName+"%")
+	}
+	if params.Species != "" {
+		query = query.Where("LOWER(pets.species) = LOWER(?)", params.Species)
+	}
+	if params.Breed != "" {
+		query = query.Where("LOWER(pets.breed) = LOWER(?)", params.Breed)
+	}
+	if params.Status != "" {
+		query = query.Where("pets.status = ?", params.Status)
+	} else {
+		query = withPetStatus(query, params.IncludeArchived)
+	}
+	if params.CreatedFrom != nil {
+		query = query.Where("pets.created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedTo != nil {
+		query = query.Where("pets.created_at < ?", *params.CreatedTo)
+	}
+	return query
+}
+
+func encodePetCursor(sort, field string, last model.Pet) string {
+	cursor := petCursor{Sort: sort, ID: last.ID}
+	switch field {
+	case "name":
+		cursor.Value = last.Name
+	case "species":
+		cursor.Value = last.Species
+	case "breed":
+		cursor.Value = last.Breed
+	case "created_at":
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = last.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePetCursor(s, sort string) (petCursor, error) {
+	var cursor petCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.Sort != sort {
+		return petCursor{}, ErrInvalidPetCursor
+	}
+	return cursor, nil
+}
+
+func petCursorValue(field, value string) (interface{}, error) {
+	if field != "created_at" && field != "updated_at" {
+		return value, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, ErrInvalidPetCursor
+	}
+	return t, nil
+}
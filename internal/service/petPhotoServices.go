@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/imaging"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+)
+
+var ErrNoPetPhoto = errors.New("the pet has no photo")
+var ErrUnknownPhotoSize = errors.New("photo size must be large, medium or small")
+
+type petPhotoSize struct {
+	Name   string
+	Pixels int
+}
+
+// petPhotoSizes are the stored versions of a profile photo and the length of
+// their longer side, largest first as each is scaled from the one before.
+var petPhotoSizes = []petPhotoSize{
+	{Name: "large", Pixels: 1600},
+	{Name: "medium", Pixels: 512},
+	{Name: "small", Pixels: 128},
+}
+
+// pet photos are stored apart from the documents, as
+// "/uploads/photos/pets/{petID}/{size}.jpg"
+func petPhotoDir(petID uint) string {
+	return filepath.Join("uploads", "photos", "pets", fmt.Sprint(petID))
+}
+
+// setPetPhotoURLs fills in the photo URLs of a pet that has a photo. The
+// version parameter changes with every upload so clients may cache them.
+func setPetPhotoURLs(pet *model.Pet) {
+	if pet.PhotoUpdatedAt == nil {
+		return
+	}
+	pet.PhotoURLs = make(map[string]string, len(petPhotoSizes))
+	for _, size := range petPhotoSizes {
+		pet.PhotoURLs[size.Name] = fmt.Sprintf("/pets/%d/photo/%s?v=%d", pet.ID, size.Name, pet.PhotoUpdatedAt.Unix())
+	}
+}
+
+// SetPetPhoto replaces a pet's profile photo. The image is decoded, turned
+// upright and re-encoded in every size, which strips its EXIF metadata.
+func (perService *PetService) SetPetPhoto(petID uint, data []byte, ctx context.Context) (model.Pet, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SetPetPhoto Service")
+	pet, err := perService.GetPet(petID, ctx)
+	if err != nil {
+		return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(pet), model.HouseholdAccessManage, model.PermissionPetsWriteAll, ctx); err != nil {
+		return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+	}
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+	}
+
+	dir := petPhotoDir(petID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+	}
+	for _, size := range petPhotoSizes {
+		img = imaging.Fit(img, size.Pixels)
+		if err := writePetPhoto(filepath.Join(dir, size.Name+".jpg"), img); err != nil {
+			return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+		}
+	}
+
+	now := time.Now()
+	if err := initializers.DB.Model(&pet).Update("photo_updated_at", now).Error; err != nil {
+		return model.Pet{}, fmt.Errorf("setting photo of pet %d: %w", petID, err)
+	}
+	pet.PhotoUpdatedAt = &now
+	setPetPhotoURLs(&pet)
+	return pet, nil
+}
+
+// writePetPhoto writes through a temporary file so a photo being replaced is
+// never served half written.
+func writePetPhoto(path string, img *image.RGBA) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*.jpg")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := imaging.EncodeJPEG(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (perService *PetService) DeletePetPhoto(petID uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeletePetPhoto Service")
+	pet, err := perService.GetPet(petID, ctx)
+	if err != nil {
+		return fmt.Errorf("deleting photo of pet %d: %w", petID, err)
+	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(pet), model.HouseholdAccessManage, model.PermissionPetsWriteAll, ctx); err != nil {
+		return fmt.Errorf("deleting photo of pet %d: %w", petID, err)
+	}
+	if pet.PhotoUpdatedAt == nil {
+		return ErrNoPetPhoto
+	}
+	if err := initializers.DB.Model(&pet).Update("photo_updated_at", nil).Error; err != nil {
+		return fmt.Errorf("deleting photo of pet %d: %w", petID, err)
+	}
+	if err := os.RemoveAll(petPhotoDir(petID)); err != nil {
+		l.Error().Err(err).Uint("petID", petID).Msg("Failed to remove pet photo files")
+	}
+	return nil
+}
+
+// GetPetPhotoPath returns the file of one size of a pet's profile photo.
+func (perService *PetService) GetPetPhotoPath(petID uint, size string, ctx context.Context) (string, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetPetPhotoPath Service")
+	pet, err := perService.GetPet(petID, ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting photo of pet %d: %w", petID, err)
+	}
+	known := false
+	for _, photoSize := range petPhotoSizes {
+		known = known || photoSize.Name == size
+	}
+	if !known {
+		return "", ErrUnknownPhotoSize
+	}
+	if pet.PhotoUpdatedAt == nil {
+		return "", ErrNoPetPhoto
+	}
+	return filepath.Join(petPhotoDir(petID), size+".jpg"), nil
+}
@@ -36,23 +36,56 @@ func (perService *PetService) GetPet(id uint, ctx context.Context) (model.Pet, e
 		}
 	}
 
-	err := validators.ValidateResourceOwner(pet.OwnerID, ctx)
-	if err != nil {
-		return model.Pet{}, validators.ResourceNotOwnedError{}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(pet), model.HouseholdAccessView, model.PermissionPetsReadAll, ctx); err != nil {
+		return model.Pet{}, fmt.Errorf("getting pet %d: %w", id, err)
 	}
 
 	return pet, nil
 
 }
 
+// GetPetRecord returns a pet together with the medications it is currently
+// on and its photo URLs. Staff also get the pet's alerts.
+func (perService *PetService) GetPetRecord(id uint, ctx context.Context) (model.Pet, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetPetRecord Service")
+	pet, err := perService.GetPet(id, ctx)
+	if err != nil {
+		return model.Pet{}, err
+	}
+	if middleware.HasPermission(ctx, model.PermissionPetsReadAll) {
+		if err := initializers.DB.Where("pet_id = ?", id).Order(petAlertOrder).Find(&pet.Alerts).Error; err != nil {
+			return model.Pet{}, fmt.Errorf("getting pet %d: %w", id, err)
+		}
+	}
+	prescriptionService := &PrescriptionService{}
+	pet.ActiveMedications, err = prescriptionService.ActivePrescriptions(id, ctx)
+	if err != nil {
+		return model.Pet{}, fmt.Errorf("getting pet %d: %w", id, err)
+	}
+	setPetPhotoURLs(&pet)
+	return pet, nil
+}
+
 func (perService *PetService) AddPet(pet *model.Pet, ctx context.Context) error {
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside AddPet Service")
 	ownerID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
 	pet.OwnerID = ownerID
+	// Pets are shared with a household through SetPetHousehold and change
+	// status through SetPetStatus.
+	pet.HouseholdID = nil
+	pet.Status = model.PetStatusActive
+	pet.StatusChangedAt, pet.StatusNote, pet.DeceasedOn = nil, "", nil
+	if err := normalizePetCatalogFields(initializers.DB, pet); err != nil {
+		return fmt.Errorf("adding pet: %w", err)
+	}
+	if err := applyMicrochip(initializers.DB, pet); err != nil {
+		return fmt.Errorf("adding pet: %w", err)
+	}
 	tx := initializers.DB.Create(pet)
 	if err := tx.Error; err != nil {
-		return fmt.Errorf("adding pet: %w", err)
+		return fmt.Errorf("adding pet: %w", microchipSaveError(err))
 	}
 	return nil
 }
@@ -64,6 +97,9 @@ func (perService *PetService) UpdatePet(id uint, pet *model.Pet, ctx context.Con
 	if err != nil {
 		return fmt.Errorf("updating pet %d: %w", id, err)
 	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(existingPet), model.HouseholdAccessManage, model.PermissionPetsWriteAll, ctx); err != nil {
+		return fmt.Errorf("updating pet %d: %w", id, err)
+	}
 
 	if pet.Name != "" {
 		existingPet.Name = pet.Name
@@ -74,58 +110,66 @@ func (perService *PetService) UpdatePet(id uint, pet *model.Pet, ctx context.Con
 	if pet.Breed != "" {
 		existingPet.Breed = pet.Breed
 	}
-	if pet.OwnerID != 0 {
-		userService := &UserService{}
-		if _, err := userService.GetUser(pet.OwnerID, ctx); err != nil {
+	if pet.Species != "" || pet.Breed != "" {
+		// A new species also has to have the pet's breed.
+		if err := normalizePetCatalogFields(initializers.DB, &existingPet); err != nil {
 			return fmt.Errorf("updating pet %d: %w", id, err)
 		}
-		existingPet.OwnerID = pet.OwnerID
 	}
 	if pet.MedicalHistory != "" {
 		existingPet.MedicalHistory = pet.MedicalHistory
 	}
+	if pet.Microchip != nil {
+		number, err := validators.NormalizeMicrochip(*pet.Microchip)
+		if err != nil {
+			return fmt.Errorf("updating pet %d: %w", id, err)
+		}
+		if existingPet.Microchip == nil || *existingPet.Microchip != number {
+			// A new chip gets its own registration date.
+			existingPet.Microchip = &number
+			existingPet.MicrochipRegisteredAt = nil
+		}
+	}
+	if pet.MicrochipRegisteredAt != nil {
+		existingPet.MicrochipRegisteredAt = pet.MicrochipRegisteredAt
+	}
+	if err := applyMicrochip(initializers.DB, &existingPet); err != nil {
+		return fmt.Errorf("updating pet %d: %w", id, err)
+	}
 	tx := initializers.DB.Model(&existingPet).Updates(existingPet)
 	if err := tx.Error; err != nil {
-		return fmt.Errorf("updating pet %d: %w", id, err)
+		return fmt.Errorf("updating pet %d: %w", id, microchipSaveError(err))
 	}
 	*pet = existingPet
 	return nil
 }
 
+// DeletePet archives a pet rather than deleting it, so that its history
+// stays readable.
 func (perService *PetService) DeletePet(id uint, ctx context.Context) error {
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside DeletePet Service")
-	pet, err := perService.GetPet(id, ctx)
-	if err != nil {
-		return fmt.Errorf("deleting pet %d: %w", id, err)
-	}
-
-	if err := initializers.DB.Delete(&pet).Error; err != nil {
+	if _, err := perService.SetPetStatus(id, PetStatusParams{Status: model.PetStatusArchived}, ctx); err != nil {
 		return fmt.Errorf("deleting pet %d: %w", id, err)
 	}
 	return nil
 }
 
-func (perService *PetService) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	l := zerolog.Ctx(ctx)
-	l.Trace().Msg("Inside GetAllPets Service")
-	var pets []model.Pet
-	tx := initializers.DB.Find(&pets)
-	if err := tx.Error; err != nil {
-		return nil, fmt.Errorf("getting all pets: %w", err)
-	}
-
-	return pets, nil
-}
-
-func (perService *PetService) GetPetsByOwner(ctx context.Context) ([]model.Pet, error) {
+// GetPetsByOwner returns the current user's pets and the pets shared with
+// their households. Archived pets are left out unless includeArchived is
+// set.
+func (perService *PetService) GetPetsByOwner(includeArchived bool, ctx context.Context) ([]model.Pet, error) {
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside GetPetsByOwner Service")
 	var pets []model.Pet
-	tx := initializers.DB.Where("owner_id = ?", ctx.Value(middleware.ContextKeyUserID)).Find(&pets)
+	userID := ctx.Value(middleware.ContextKeyUserID)
+	tx := withPetStatus(initializers.DB, includeArchived).Where("owner_id = ? OR household_id IN (?)", userID, memberHouseholds(initializers.DB, userID)).Find(&pets)
 	if err := tx.Error; err != nil {
 		return nil, fmt.Errorf("getting pets by owner: %w", err)
 	}
+	for i := range pets {
+		setPetPhotoURLs(&pets[i])
+	}
 
 	return pets, nil
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// PetNotActiveError is returned when booking or transferring a pet that is
+// deceased, transferred or archived.
+type PetNotActiveError struct {
+	ID     uint
+	Status string
+}
+
+func (e PetNotActiveError) Error() string {
+	return fmt.Sprintf("pet with ID %d is %s", e.ID, e.Status)
+}
+
+var ErrInvalidPetStatus = errors.New("status must be one of active, deceased, transferred or archived")
+var ErrDeceasedOnInFuture = errors.New("deceased_on cannot be in the future")
+var ErrDeceasedOnNotAllowed = errors.New("deceased_on is only allowed for deceased pets")
+var ErrPetDeceased = errors.New("only clinic staff can change the status of a deceased pet")
+
+type PetStatusParams struct {
+	Status string `json:"status" enums:"active,deceased,transferred,archived" example:"deceased"`
+	// DeceasedOn defaults to today for deceased pets.
+	DeceasedOn *time.Time `json:"deceased_on" example:"2024-05-01T00:00:00Z"`
+	Note       string     `json:"note" example:"Passed away peacefully at home"`
+}
+
+type PetStatusChange struct {
+	Pet model.Pet `json:"pet"`
+	// CancelledAppointments counts the future appointments cancelled because
+	// the pet is no longer active.
+	CancelledAppointments int64 `json:"cancelled_appointments" example:"2"`
+}
+
+// SetPetStatus moves a pet through its lifecycle. A pet that leaves the
+// active status keeps its records, but its future appointments and pending
+// transfers are cancelled. Only staff with pets:write_all can make a
+// deceased pet active again, e.g. to correct a mistake.
+func (perService *PetService) SetPetStatus(id uint, params PetStatusParams, ctx context.Context) (PetStatusChange, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SetPetStatus Service")
+	pet, err := perService.GetPet(id, ctx)
+	if err != nil {
+		return PetStatusChange{}, fmt.Errorf("setting status of pet %d: %w", id, err)
+	}
+	if err := validators.ValidateResourceOwner(validators.PetOwner(pet), model.HouseholdAccessManage, model.PermissionPetsWriteAll, ctx); err != nil {
+		return PetStatusChange{}, fmt.Errorf("setting status of pet %d: %w", id, err)
+	}
+	if !model.IsValidPetStatus(params.Status) {
+		return PetStatusChange{}, ErrInvalidPetStatus
+	}
+	if params.DeceasedOn != nil && params.Status != model.PetStatusDeceased {
+		return PetStatusChange{}, ErrDeceasedOnNotAllowed
+	}
+
+	now := time.Now()
+	switch {
+	case params.Status == model.PetStatusDeceased:
+		if params.DeceasedOn == nil {
+			params.DeceasedOn = &now
+		}
+		if params.DeceasedOn.After(now) {
+			return PetStatusChange{}, ErrDeceasedOnInFuture
+		}
+	case params.Status == model.PetStatusArchived:
+		// An archived pet that had died is still deceased.
+		params.DeceasedOn = pet.DeceasedOn
+	case pet.DeceasedOn != nil && !middleware.HasPermission(ctx, model.PermissionPetsWriteAll):
+		return PetStatusChange{}, ErrPetDeceased
+	}
+	if pet.Status != params.Status {
+		pet.StatusChangedAt = &now
+	}
+	pet.Status = params.Status
+	pet.StatusNote = strings.TrimSpace(params.Note)
+	pet.DeceasedOn = params.DeceasedOn
+
+	var change PetStatusChange
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":            pet.Status,
+			"status_changed_at": pet.StatusChangedAt,
+			"status_note":       pet.StatusNote,
+			"deceased_on":       pet.DeceasedOn,
+		}
+		if err := tx.Model(&pet).Updates(updates).Error; err != nil {
+			return err
+		}
+		if pet.Status == model.PetStatusActive {
+			return nil
+		}
+		var err error
+		change.CancelledAppointments, err = cancelPetFuture(tx, pet.ID)
+		return err
+	})
+	if err != nil {
+		return PetStatusChange{}, fmt.Errorf("setting status of pet %d: %w", id, err)
+	}
+	l.Info().Uint("petID", id).Str("status", pet.Status).Int64("cancelledAppointments", change.CancelledAppointments).Msg("Pet status changed")
+	setPetPhotoURLs(&pet)
+	change.Pet = pet
+	return change, nil
+}
+
+// cancelPetFuture cancels the future appointments and pending transfers of a
+// pet that is no longer active and returns how many appointments it
+// cancelled.
+func cancelPetFuture(tx *gorm.DB, petID uint) (int64, error) {
+	result := tx.Where("pet_id = ? AND slot > ?", petID, time.Now()).Delete(&model.Appointment{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	err := tx.Model(&model.PetTransfer{}).
+		Where("pet_id = ? AND status = ?", petID, model.PetTransferStatusPending).
+		Updates(map[string]interface{}{"status": model.PetTransferStatusCancelled, "responded_at": time.Now()}).Error
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// ensurePetActive fails with PetNotActiveError for a deceased, transferred
+// or archived pet.
+func ensurePetActive(pet model.Pet) error {
+	if pet.Status != "" && pet.Status != model.PetStatusActive {
+		return PetNotActiveError{ID: pet.ID, Status: pet.Status}
+	}
+	return nil
+}
+
+func withPetStatus(db *gorm.DB, includeArchived bool) *gorm.DB {
+	if includeArchived {
+		return db
+	}
+	return db.Where("pets.status <> ?", model.PetStatusArchived)
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/mailer"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type PetTransferNotFoundError struct {
+	ID uint
+}
+
+func (e PetTransferNotFoundError) Error() string {
+	return fmt.Sprintf("pet transfer with ID %d not found", e.ID)
+}
+
+var ErrTransferToSelf = errors.New("the pet already belongs to this user")
+var ErrTransferPending = errors.New("the pet already has a pending transfer")
+var ErrTransferNotPending = errors.New("the transfer is no longer pending")
+var ErrNotTransferRecipient = errors.New("only the recipient can accept or decline a transfer")
+
+const petTransferPendingIndex = "idx_pet_transfers_pending"
+
+type PetTransferParams struct {
+	Email string `json:"email" example:"new.owner@example.com"`
+	Note  string `json:"note" example:"Rehomed to a family friend"`
+}
+
+// StartPetTransfer offers a pet to whoever has the account with the given
+// email. The transfer is stored and returned the same way whether or not
+// such an account exists. The pet's owner and staff with pets:write_all may
+// start a transfer.
+func (petTransferService *PetTransferService) StartPetTransfer(petID uint, params PetTransferParams, ctx context.Context) (model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside StartPetTransfer Service")
+	petService := &PetService{}
+	pet, err := petService.GetPet(petID, ctx)
+	if err != nil {
+		return model.PetTransfer{}, fmt.Errorf("starting transfer of pet %d: %w", petID, err)
+	}
+	if err := validators.ValidateResourceOwner(validators.Owner{UserID: pet.OwnerID}, "", model.PermissionPetsWriteAll, ctx); err != nil {
+		return model.PetTransfer{}, fmt.Errorf("starting transfer of pet %d: %w", petID, err)
+	}
+	if err := ensurePetActive(pet); err != nil {
+		return model.PetTransfer{}, fmt.Errorf("starting transfer of pet %d: %w", petID, err)
+	}
+
+	email, err := validators.NormalizeEmail(params.Email)
+	if err != nil {
+		return model.PetTransfer{}, err
+	}
+	var owner model.User
+	if err := initializers.DB.First(&owner, pet.OwnerID).Error; err != nil {
+		return model.PetTransfer{}, fmt.Errorf("starting transfer of pet %d: %w", petID, err)
+	}
+	if strings.EqualFold(owner.Email, email) {
+		return model.PetTransfer{}, ErrTransferToSelf
+	}
+
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	transfer := model.PetTransfer{
+		PetID:         petID,
+		FromOwnerID:   pet.OwnerID,
+		ToEmail:       email,
+		InitiatedByID: userID,
+		Note:          strings.TrimSpace(params.Note),
+		Status:        model.PetTransferStatusPending,
+	}
+	if err := initializers.DB.Create(&transfer).Error; err != nil {
+		if utils.IsUniqueViolation(err, petTransferPendingIndex) {
+			return model.PetTransfer{}, ErrTransferPending
+		}
+		return model.PetTransfer{}, fmt.Errorf("starting transfer of pet %d: %w", petID, err)
+	}
+	transfer.Pet = pet
+
+	var recipient model.User
+	tx := initializers.DB.Where("LOWER(email) = LOWER(?) AND disabled = ?", email, false).First(&recipient)
+	if err := tx.Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			l.Error().Err(err).Uint("transferID", transfer.ID).Msg("Failed to look up the recipient of a pet transfer")
+		}
+		return transfer, nil
+	}
+	msg := mailer.Message{
+		To:      recipient.Email,
+		Subject: fmt.Sprintf("%s is being transferred to you", pet.Name),
+		Body: fmt.Sprintf("Hello %s,\n\nYou have been offered %s (%s, %s) at the clinic. Log in at %s to accept or decline the transfer.\n",
+			recipient.Name, pet.Name, pet.Species, pet.Breed, frontendURL()),
+	}
+	if err := mailer.Get().Send(ctx, msg); err != nil {
+		l.Error().Err(err).Uint("transferID", transfer.ID).Msg("Failed to notify the recipient of a pet transfer")
+	}
+	return transfer, nil
+}
+
+// ListPetTransfers returns the transfer history of a pet, newest first.
+func (petTransferService *PetTransferService) ListPetTransfers(petID uint, ctx context.Context) ([]model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListPetTransfers Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return nil, fmt.Errorf("listing transfers of pet %d: %w", petID, err)
+	}
+	transfers := []model.PetTransfer{}
+	query := initializers.DB.Preload("Pet")
+	if middleware.HasPermission(ctx, model.PermissionPetsReadAll) {
+		query = withPetAlerts(query, "Pet.Alerts")
+	}
+	tx := query.Where("pet_id = ?", petID).Order("created_at DESC").Find(&transfers)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing transfers of pet %d: %w", petID, tx.Error)
+	}
+	return transfers, nil
+}
+
+// ListMyPetTransfers returns the transfers the current user gives or
+// receives, newest first.
+func (petTransferService *PetTransferService) ListMyPetTransfers(ctx context.Context) ([]model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListMyPetTransfers Service")
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	var user model.User
+	if err := initializers.DB.First(&user, userID).Error; err != nil {
+		return nil, fmt.Errorf("listing transfers of user %d: %w", userID, err)
+	}
+	transfers := []model.PetTransfer{}
+	query := initializers.DB.Preload("Pet")
+	if middleware.HasPermission(ctx, model.PermissionPetsReadAll) {
+		query = withPetAlerts(query, "Pet.Alerts")
+	}
+	// Unanswered transfers are addressed by email, which only counts once
+	// it is verified.
+	if user.EmailVerifiedAt != nil {
+		query = query.Where("from_owner_id = ? OR to_user_id = ? OR (to_user_id IS NULL AND LOWER(to_email) = LOWER(?))", userID, userID, user.Email)
+	} else {
+		query = query.Where("from_owner_id = ? OR to_user_id = ?", userID, userID)
+	}
+	tx := query.
+		Order("created_at DESC").
+		Find(&transfers)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing transfers of user %d: %w", userID, tx.Error)
+	}
+	return transfers, nil
+}
+
+// AcceptPetTransfer makes the recipient the pet's owner. Appointments,
+// documents and the rest of the pet's record are keyed by the pet, so they
+// move with it.
+func (petTransferService *PetTransferService) AcceptPetTransfer(id uint, ctx context.Context) (model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AcceptPetTransfer Service")
+	return petTransferService.respond(id, model.PetTransferStatusAccepted, ctx)
+}
+
+func (petTransferService *PetTransferService) DeclinePetTransfer(id uint, ctx context.Context) (model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeclinePetTransfer Service")
+	return petTransferService.respond(id, model.PetTransferStatusDeclined, ctx)
+}
+
+// CancelPetTransfer withdraws a pending transfer. The pet's owner, whoever
+// started the transfer and staff with pets:write_all may cancel it.
+func (petTransferService *PetTransferService) CancelPetTransfer(id uint, ctx context.Context) (model.PetTransfer, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CancelPetTransfer Service")
+	transfer, err := findPetTransfer(initializers.DB, id)
+	if err != nil {
+		return model.PetTransfer{}, fmt.Errorf("cancelling pet transfer %d: %w", id, err)
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	if userID != transfer.InitiatedByID {
+		if err := validators.ValidateResourceOwner(validators.Owner{UserID: transfer.FromOwnerID}, "", model.PermissionPetsWriteAll, ctx); err != nil {
+			return model.PetTransfer{}, fmt.Errorf("cancelling pet transfer %d: %w", id, err)
+		}
+	}
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		return closePetTransfer(tx, &transfer, model.PetTransferStatusCancelled)
+	}); err != nil {
+		return model.PetTransfer{}, fmt.Errorf("cancelling pet transfer %d: %w", id, err)
+	}
+	return transfer, nil
+}
+
+func (petTransferService *PetTransferService) respond(id uint, status string, ctx context.Context) (model.PetTransfer, error) {
+	transfer, err := findPetTransfer(initializers.DB, id)
+	if err != nil {
+		return model.PetTransfer{}, fmt.Errorf("answering pet transfer %d: %w", id, err)
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	if err := ensureTransferRecipient(transfer, userID); err != nil {
+		return model.PetTransfer{}, fmt.Errorf("answering pet transfer %d: %w", id, err)
+	}
+	transfer.ToUserID = &userID
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := closePetTransfer(tx, &transfer, status); err != nil {
+			return err
+		}
+		if status != model.PetTransferStatusAccepted {
+			return nil
+		}
+		// The owner may have changed since the transfer was started, e.g.
+		// through another transfer that was cancelled and redone. The pet
+		// leaves the previous owner's household; the new owner may share it
+		// with one of theirs.
+		result := tx.Model(&model.Pet{}).
+			Where("id = ? AND owner_id = ?", transfer.PetID, transfer.FromOwnerID).
+			Updates(map[string]interface{}{"owner_id": userID, "household_id": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransferNotPending
+		}
+		transfer.Pet.OwnerID = userID
+		transfer.Pet.HouseholdID = nil
+		return nil
+	})
+	if err != nil {
+		return model.PetTransfer{}, fmt.Errorf("answering pet transfer %d: %w", id, err)
+	}
+	zerolog.Ctx(ctx).Info().
+		Uint("transferID", transfer.ID).
+		Uint("petID", transfer.PetID).
+		Uint("fromOwnerID", transfer.FromOwnerID).
+		Uint("toUserID", userID).
+		Str("status", status).
+		Msg("Pet transfer answered")
+	return transfer, nil
+}
+
+// ensureTransferRecipient fails unless userID may answer a transfer: the
+// account that already answered it or, for an unanswered one, an account
+// whose verified email it was sent to.
+func ensureTransferRecipient(transfer model.PetTransfer, userID uint) error {
+	if transfer.ToUserID != nil {
+		if *transfer.ToUserID != userID {
+			return ErrNotTransferRecipient
+		}
+		return nil
+	}
+	var user model.User
+	if err := initializers.DB.First(&user, userID).Error; err != nil {
+		return err
+	}
+	if !strings.EqualFold(user.Email, transfer.ToEmail) {
+		return ErrNotTransferRecipient
+	}
+	if user.EmailVerifiedAt == nil {
+		return ErrEmailNotVerified
+	}
+	if user.ID == transfer.FromOwnerID {
+		return ErrTransferToSelf
+	}
+	return nil
+}
+
+// closePetTransfer moves a pending transfer to its final status, failing if
+// it was answered or cancelled in the meantime. The recipient, if known, is
+// recorded with it.
+func closePetTransfer(tx *gorm.DB, transfer *model.PetTransfer, status string) error {
+	now := time.Now()
+	result := tx.Model(transfer).
+		Where("status = ?", model.PetTransferStatusPending).
+		Updates(map[string]interface{}{"status": status, "responded_at": now, "to_user_id": transfer.ToUserID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransferNotPending
+	}
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	return nil
+}
+
+func findPetTransfer(db *gorm.DB, id uint) (model.PetTransfer, error) {
+	var transfer model.PetTransfer
+	if err := db.Preload("Pet").First(&transfer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.PetTransfer{}, PetTransferNotFoundError{ID: id}
+		}
+		return model.PetTransfer{}, err
+	}
+	return transfer, nil
+}
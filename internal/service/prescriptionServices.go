@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type PrescriptionNotFoundError struct {
+	ID uint
+}
+
+func (e PrescriptionNotFoundError) Error() string {
+	return fmt.Sprintf("prescription with ID %d not found", e.ID)
+}
+
+var ErrPrescriptionIncomplete = errors.New("drug, dose and frequency are required")
+var ErrInvalidDuration = errors.New("duration_days must be at least 1")
+var ErrInvalidRefills = errors.New("refills cannot be negative")
+var ErrAppointmentOfOtherPet = errors.New("the appointment is for a different pet")
+var ErrNoRefillsRemaining = errors.New("no refills remaining")
+var ErrPrescriptionInactive = errors.New("prescription has been discontinued")
+
+type PrescriptionParams struct {
+	AppointmentID *uint      `json:"appointment_id" example:"12"`
+	Drug          string     `json:"drug" example:"Carprofen"`
+	Dose          string     `json:"dose" example:"50 mg"`
+	Frequency     string     `json:"frequency" example:"Twice daily with food"`
+	DurationDays  int        `json:"duration_days" example:"14"`
+	Refills       int        `json:"refills" example:"2"`
+	StartsAt      *time.Time `json:"starts_at" example:"2026-10-01T00:00:00Z"`
+}
+
+// CreatedPrescription is returned when prescribing. Warnings do not stop the
+// prescription from being created but should be shown to the vet.
+type CreatedPrescription struct {
+	Prescription model.Prescription `json:"prescription"`
+	Warnings     []string           `json:"warnings" example:"Carprofen is already prescribed to this pet until 2026-10-15"`
+}
+
+func (prescriptionService *PrescriptionService) ListPrescriptions(petID uint, ctx context.Context) ([]model.Prescription, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListPrescriptions Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return nil, fmt.Errorf("listing prescriptions of pet %d: %w", petID, err)
+	}
+	prescriptions := []model.Prescription{}
+	tx := initializers.DB.Where("pet_id = ?", petID).Order("starts_at DESC").Find(&prescriptions)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing prescriptions of pet %d: %w", petID, tx.Error)
+	}
+	return prescriptions, nil
+}
+
+// ActivePrescriptions returns the medications a pet is currently on. The
+// caller is expected to have checked access to the pet.
+func (prescriptionService *PrescriptionService) ActivePrescriptions(petID uint, ctx context.Context) ([]model.Prescription, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ActivePrescriptions Service")
+	prescriptions := []model.Prescription{}
+	tx := activePrescriptions(initializers.DB, petID, time.Now()).Order("drug ASC").Find(&prescriptions)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("getting active prescriptions of pet %d: %w", petID, tx.Error)
+	}
+	return prescriptions, nil
+}
+
+// Prescribe creates a prescription written by the current user.
+func (prescriptionService *PrescriptionService) Prescribe(petID uint, params PrescriptionParams, ctx context.Context) (CreatedPrescription, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside Prescribe Service")
+	petService := &PetService{}
+	pet, err := petService.GetPet(petID, ctx)
+	if err != nil {
+		return CreatedPrescription{}, fmt.Errorf("prescribing: %w", err)
+	}
+	params.Drug = strings.TrimSpace(params.Drug)
+	params.Dose = strings.TrimSpace(params.Dose)
+	params.Frequency = strings.TrimSpace(params.Frequency)
+	if params.Drug == "" || params.Dose == "" || params.Frequency == "" {
+		return CreatedPrescription{}, ErrPrescriptionIncomplete
+	}
+	if params.DurationDays < 1 {
+		return CreatedPrescription{}, ErrInvalidDuration
+	}
+	if params.Refills < 0 {
+		return CreatedPrescription{}, ErrInvalidRefills
+	}
+	if params.AppointmentID != nil {
+		appointmentService := &AppointmentService{}
+		appointment, err := appointmentService.GetAppointment(*params.AppointmentID, ctx)
+		if err != nil {
+			return CreatedPrescription{}, fmt.Errorf("prescribing: %w", err)
+		}
+		if appointment.PetID != petID {
+			return CreatedPrescription{}, ErrAppointmentOfOtherPet
+		}
+	}
+
+	startsAt := time.Now()
+	if params.StartsAt != nil {
+		startsAt = *params.StartsAt
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	created := CreatedPrescription{
+		Prescription: model.Prescription{
+			PetID:            petID,
+			AppointmentID:    params.AppointmentID,
+			Drug:             params.Drug,
+			Dose:             params.Dose,
+			Frequency:        params.Frequency,
+			DurationDays:     params.DurationDays,
+			RefillsRemaining: params.Refills,
+			PrescribedByID:   userID,
+			StartsAt:         startsAt,
+			EndsAt:           startsAt.AddDate(0, 0, params.DurationDays),
+		},
+	}
+	created.Warnings, err = prescriptionWarnings(pet, created.Prescription)
+	if err != nil {
+		return CreatedPrescription{}, fmt.Errorf("prescribing: %w", err)
+	}
+	if err := initializers.DB.Create(&created.Prescription).Error; err != nil {
+		return CreatedPrescription{}, fmt.Errorf("prescribing: %w", err)
+	}
+	if len(created.Warnings) > 0 {
+		l.Warn().Uint("petID", petID).Str("drug", params.Drug).Strs("warnings", created.Warnings).Msg("Prescribed despite warnings")
+	}
+	return created, nil
+}
+
+// RefillPrescription uses up one refill and extends the course by its
+// duration, counted from today if it has already run out.
+func (prescriptionService *PrescriptionService) RefillPrescription(petID, id uint, ctx context.Context) (model.Prescription, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RefillPrescription Service")
+	prescription, err := prescriptionService.getPrescription(petID, id, ctx)
+	if err != nil {
+		return model.Prescription{}, fmt.Errorf("refilling prescription %d: %w", id, err)
+	}
+	if prescription.DiscontinuedAt != nil {
+		return model.Prescription{}, ErrPrescriptionInactive
+	}
+	now := time.Now()
+	from := prescription.EndsAt
+	if from.Before(now) {
+		from = now
+	}
+	endsAt := from.AddDate(0, 0, prescription.DurationDays)
+	result := initializers.DB.Model(&prescription).
+		Where("refills_remaining > 0 AND discontinued_at IS NULL").
+		Updates(map[string]interface{}{
+			"refills_remaining": gorm.Expr("refills_remaining - 1"),
+			"ends_at":           endsAt,
+			"last_refilled_at":  now,
+		})
+	if result.Error != nil {
+		return model.Prescription{}, fmt.Errorf("refilling prescription %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.Prescription{}, ErrNoRefillsRemaining
+	}
+	prescription.RefillsRemaining--
+	prescription.EndsAt = endsAt
+	prescription.LastRefilledAt = &now
+	return prescription, nil
+}
+
+// DiscontinuePrescription stops a medication before its course ends.
+func (prescriptionService *PrescriptionService) DiscontinuePrescription(petID, id uint, ctx context.Context) (model.Prescription, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DiscontinuePrescription Service")
+	prescription, err := prescriptionService.getPrescription(petID, id, ctx)
+	if err != nil {
+		return model.Prescription{}, fmt.Errorf("discontinuing prescription %d: %w", id, err)
+	}
+	if prescription.DiscontinuedAt != nil {
+		return prescription, nil
+	}
+	now := time.Now()
+	if err := initializers.DB.Model(&prescription).Update("discontinued_at", now).Error; err != nil {
+		return model.Prescription{}, fmt.Errorf("discontinuing prescription %d: %w", id, err)
+	}
+	prescription.DiscontinuedAt = &now
+	return prescription, nil
+}
+
+func (prescriptionService *PrescriptionService) getPrescription(petID, id uint, ctx context.Context) (model.Prescription, error) {
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.Prescription{}, err
+	}
+	var prescription model.Prescription
+	if err := initializers.DB.Where("pet_id = ?", petID).First(&prescription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Prescription{}, PrescriptionNotFoundError{ID: id}
+		}
+		return model.Prescription{}, err
+	}
+	return prescription, nil
+}
+
+// prescriptionWarnings flags a course of a drug that overlaps one the pet is
+// already on, a drug matching one of its allergy alerts, and a drug its
+// medical history mentions, which is usually an allergy or a bad reaction.
+func prescriptionWarnings(pet model.Pet, prescription model.Prescription) ([]string, error) {
+	warnings := []string{}
+	drug := prescription.Drug
+	var duplicates []model.Prescription
+	tx := initializers.DB.
+		Where("pet_id = ? AND discontinued_at IS NULL AND LOWER(drug) = LOWER(?)", pet.ID, drug).
+		Where("starts_at < ? AND ends_at > ?", prescription.EndsAt, prescription.StartsAt).
+		Find(&duplicates)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	for _, duplicate := range duplicates {
+		warnings = append(warnings, fmt.Sprintf("%s is already prescribed to this pet until %s", duplicate.Drug, duplicate.EndsAt.Format(time.DateOnly)))
+	}
+	var allergies []model.PetAlert
+	if err := initializers.DB.Where("pet_id = ? AND kind = ?", pet.ID, model.PetAlertKindAllergy).Find(&allergies).Error; err != nil {
+		return nil, err
+	}
+	lowerDrug := strings.ToLower(drug)
+	for _, allergy := range allergies {
+		lowerAllergy := strings.ToLower(allergy.Description)
+		if strings.Contains(lowerAllergy, lowerDrug) || strings.Contains(lowerDrug, lowerAllergy) {
+			warnings = append(warnings, fmt.Sprintf("the pet has a %s severity allergy alert: %s", allergy.Severity, allergy.Description))
+		}
+	}
+	if strings.Contains(strings.ToLower(pet.MedicalHistory), lowerDrug) {
+		warnings = append(warnings, fmt.Sprintf("the pet's medical history mentions %s, check for an allergy", drug))
+	}
+	return warnings, nil
+}
+
+func activePrescriptions(db *gorm.DB, petID uint, at time.Time) *gorm.DB {
+	return db.Where("pet_id = ? AND discontinued_at IS NULL AND starts_at <= ? AND ends_at > ?", petID, at, at)
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/authz"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type RoleNotFoundError struct {
+	Name string
+}
+
+func (e RoleNotFoundError) Error() string {
+	return fmt.Sprintf("role %q not found", e.Name)
+}
+
+var ErrRoleExists = errors.New("role already exists")
+var ErrBuiltInRole = errors.New("built-in roles cannot be deleted and the admin role cannot be edited")
+var ErrRoleInUse = errors.New("role is still assigned to users")
+var ErrPermissionNotHeld = errors.New("you cannot grant a permission you do not hold")
+var ErrCannotEditOwnRole = errors.New("you cannot edit your own role")
+var ErrInvalidRoleName = errors.New("role names must be 2 to 32 lowercase letters, digits, '-' or '_', starting with a letter")
+
+type InvalidPermissionError struct {
+	Permission string
+}
+
+func (e InvalidPermissionError) Error() string {
+	return fmt.Sprintf("unknown permission %q", e.Permission)
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+type RoleParams struct {
+	Name        string   `json:"name" example:"receptionist"`
+	Description string   `json:"description" example:"Front desk"`
+	Permissions []string `json:"permissions" example:"appointments:read_all,appointments:manage"`
+}
+
+// validateRoleExists returns ErrInvalidRole unless a role with that name exists.
+func validateRoleExists(name string) error {
+	var count int64
+	if err := initializers.DB.Model(&model.Role{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// SeedRoles creates the built-in roles with their default permissions if
+// they do not exist yet. Existing roles are left as an admin configured them.
+func (roleService *RoleService) SeedRoles(ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SeedRoles Service")
+	builtIn := []model.Role{
+		{Name: model.UserTypeAdmin, Description: "Full access to everything", BuiltIn: true},
+		{Name: model.UserTypeStaff, Description: "Clinic staff", BuiltIn: true},
+		{Name: model.UserTypeOwner, Description: "Pet owner, limited to their own records", BuiltIn: true},
+	}
+	for _, role := range builtIn {
+		err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+			result := tx.Where(model.Role{Name: role.Name}).Attrs(role).FirstOrCreate(&role)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			l.Info().Str("role", role.Name).Msg("Seeded built-in role")
+			return replaceRolePermissions(tx, role.Name, model.DefaultRolePermissions[role.Name])
+		})
+		if err != nil {
+			return fmt.Errorf("seeding role %q: %w", role.Name, err)
+		}
+	}
+	authz.Invalidate()
+	return nil
+}
+
+func (roleService *RoleService) ListPermissions(ctx context.Context) []model.PermissionInfo {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListPermissions Service")
+	return model.Permissions
+}
+
+func (roleService *RoleService) ListRoles(ctx context.Context) ([]model.Role, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListRoles Service")
+	var roles []model.Role
+	if err := initializers.DB.Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("listing roles: %w", err)
+	}
+	var grants []model.RolePermission
+	if err := initializers.DB.Order("permission ASC").Find(&grants).Error; err != nil {
+		return nil, fmt.Errorf("listing roles: %w", err)
+	}
+	byRole := map[string][]string{}
+	for _, grant := range grants {
+		byRole[grant.RoleName] = append(byRole[grant.RoleName], grant.Permission)
+	}
+	for i := range roles {
+		roles[i].Permissions = rolePermissionList(roles[i].Name, byRole[roles[i].Name])
+	}
+	return roles, nil
+}
+
+func (roleService *RoleService) GetRole(name string, ctx context.Context) (model.Role, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetRole Service")
+	var role model.Role
+	if err := initializers.DB.Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Role{}, RoleNotFoundError{Name: name}
+		}
+		return model.Role{}, fmt.Errorf("getting role %q: %w", name, err)
+	}
+	var permissions []string
+	tx := initializers.DB.Model(&model.RolePermission{}).Where("role_name = ?", name).Order("permission ASC").Pluck("permission", &permissions)
+	if tx.Error != nil {
+		return model.Role{}, fmt.Errorf("getting role %q: %w", name, tx.Error)
+	}
+	role.Permissions = rolePermissionList(name, permissions)
+	return role, nil
+}
+
+// CreateRole adds a custom role. The caller can only grant permissions they
+// hold themselves.
+func (roleService *RoleService) CreateRole(params RoleParams, ctx context.Context) (model.Role, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CreateRole Service")
+	if !roleNamePattern.MatchString(params.Name) {
+		return model.Role{}, ErrInvalidRoleName
+	}
+	if err := validatePermissions(params.Permissions); err != nil {
+		return model.Role{}, err
+	}
+	if err := ensureHoldsPermissions(params.Permissions, ctx); err != nil {
+		return model.Role{}, err
+	}
+	role := model.Role{Name: params.Name, Description: params.Description}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(model.Role{Name: role.Name}).Attrs(role).FirstOrCreate(&role)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRoleExists
+		}
+		return replaceRolePermissions(tx, role.Name, params.Permissions)
+	})
+	if err != nil {
+		return model.Role{}, fmt.Errorf("creating role %q: %w", params.Name, err)
+	}
+	authz.Invalidate()
+	return roleService.GetRole(role.Name, ctx)
+}
+
+// UpdateRole replaces the description and permissions of a role. Users with
+// the role pick up the change without logging in again. The caller cannot
+// edit their own role, nor a role or permissions beyond their own.
+func (roleService *RoleService) UpdateRole(name string, params RoleParams, ctx context.Context) (model.Role, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateRole Service")
+	if name == model.UserTypeAdmin {
+		return model.Role{}, ErrBuiltInRole
+	}
+	if callerRole, _ := ctx.Value(middleware.ContextKeyRole).(string); callerRole == name {
+		return model.Role{}, ErrCannotEditOwnRole
+	}
+	if err := validatePermissions(params.Permissions); err != nil {
+		return model.Role{}, err
+	}
+	if _, err := roleService.GetRole(name, ctx); err != nil {
+		return model.Role{}, err
+	}
+	if err := ensureCanGrantRole(name, ctx); err != nil {
+		return model.Role{}, err
+	}
+	if err := ensureHoldsPermissions(params.Permissions, ctx); err != nil {
+		return model.Role{}, err
+	}
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Role{Name: name}).Update("description", params.Description).Error; err != nil {
+			return err
+		}
+		return replaceRolePermissions(tx, name, params.Permissions)
+	})
+	if err != nil {
+		return model.Role{}, fmt.Errorf("updating role %q: %w", name, err)
+	}
+	authz.Invalidate()
+	return roleService.GetRole(name, ctx)
+}
+
+// DeleteRole removes a custom role that no user holds any more.
+func (roleService *RoleService) DeleteRole(name string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeleteRole Service")
+	role, err := roleService.GetRole(name, ctx)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return ErrBuiltInRole
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var users int64
+		if err := tx.Model(&model.User{}).Where("role = ?", name).Count(&users).Error; err != nil {
+			return err
+		}
+		if users > 0 {
+			return ErrRoleInUse
+		}
+		if err := tx.Where("role_name = ?", name).Delete(&model.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Role{Name: name}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("deleting role %q: %w", name, err)
+	}
+	authz.Invalidate()
+	return nil
+}
+
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if !model.IsValidPermission(permission) {
+			return InvalidPermissionError{Permission: permission}
+		}
+	}
+	return nil
+}
+
+// ensureHoldsPermissions returns ErrPermissionNotHeld unless the caller
+// holds every one of permissions.
+func ensureHoldsPermissions(permissions []string, ctx context.Context) error {
+	for _, permission := range permissions {
+		if !middleware.HasPermission(ctx, permission) {
+			return fmt.Errorf("%w: %s", ErrPermissionNotHeld, permission)
+		}
+	}
+	return nil
+}
+
+func replaceRolePermissions(tx *gorm.DB, roleName string, permissions []string) error {
+	if err := tx.Where("role_name = ?", roleName).Delete(&model.RolePermission{}).Error; err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	grants := make([]model.RolePermission, 0, len(permissions))
+	for _, permission := range permissions {
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		grants = append(grants, model.RolePermission{RoleName: roleName, Permission: permission})
+	}
+	if len(grants) == 0 {
+		return nil
+	}
+	return tx.Omit("Role").Create(&grants).Error
+}
+
+// rolePermissionList reports the effective permissions of a role, which for
+// admin is every permission.
+func rolePermissionList(roleName string, granted []string) []string {
+	if roleName != model.UserTypeAdmin {
+		if granted == nil {
+			return []string{}
+		}
+		return granted
+	}
+	all := make([]string, 0, len(model.Permissions))
+	for _, p := range model.Permissions {
+		all = append(all, p.Name)
+	}
+	sort.Strings(all)
+	return all
+}
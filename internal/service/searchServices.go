@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+)
+
+var ErrEmptySearch = errors.New("search query must contain a letter or digit")
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxSearchTerms     = 10
+	// searchTSQuery parses the query built by buildSearchQuery.
+	searchTSQuery = "to_tsquery('simple', ?)"
+)
+
+type SearchParams struct {
+	Query string
+	// Limit caps the results of each entity type.
+	Limit int
+}
+
+type OwnerSearchHit struct {
+	ID      uint    `json:"id" example:"7"`
+	Name    string  `json:"name" example:"Jane Doe"`
+	Email   string  `json:"email" example:"jane@example.com"`
+	Contact string  `json:"contact" example:"9876543210"`
+	Role    string  `json:"role" example:"owner"`
+	Rank    float64 `json:"rank" example:"0.6"`
+}
+
+type PetSearchHit struct {
+	Pet       model.Pet `json:"pet"`
+	OwnerName string    `json:"owner_name" example:"Jane Doe"`
+	Rank      float64   `json:"rank" example:"0.6"`
+}
+
+type VisitNoteSearchHit struct {
+	ID            uint      `json:"id" example:"12"`
+	AppointmentID uint      `json:"appointment_id" example:"40"`
+	PetID         uint      `json:"pet_id" example:"3"`
+	PetName       string    `json:"pet_name" example:"Buddy"`
+	Slot          time.Time `json:"slot"`
+	// Headline is an excerpt of the note with the matches in <b> tags.
+	Headline string  `json:"headline" example:"Suspected <b>soft</b> tissue injury"`
+	Rank     float64 `json:"rank" example:"0.6"`
+}
+
+// SearchResults groups the matches by entity type, best match first.
+// Visit notes are only searched for staff with visit_notes:read.
+type SearchResults struct {
+	Query      string               `json:"query" example:"Buddy 98765"`
+	Owners     []OwnerSearchHit     `json:"owners"`
+	Pets       []PetSearchHit       `json:"pets"`
+	VisitNotes []VisitNoteSearchHit `json:"visit_notes,omitempty"`
+}
+
+// Search looks every word of the query up, as a prefix, in the full-text
+// indexes of users, pets and visit notes. A record matches when it has any
+// of the words and ranks higher the more it has, so "Buddy 98765" finds
+// both the pet named Buddy and the owner whose phone starts with 98765.
+// Only owner accounts are searched, so staff contact details stay private.
+func (searchService *SearchService) Search(params SearchParams, ctx context.Context) (SearchResults, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside Search Service")
+	tsquery := buildSearchQuery(params.Query)
+	if tsquery == "" {
+		return SearchResults{}, ErrEmptySearch
+	}
+	if params.Limit < 1 {
+		params.Limit = defaultSearchLimit
+	}
+	if params.Limit > maxSearchLimit {
+		params.Limit = maxSearchLimit
+	}
+
+	results := SearchResults{Query: params.Query, Owners: []OwnerSearchHit{}, Pets: []PetSearchHit{}}
+	tx := initializers.DB.Model(&model.User{}).
+		Select("users.id, users.name, users.email, users.contact, users.role, ts_rank(users.search_vector, "+searchTSQuery+") AS rank", tsquery).
+		Where("users.search_vector @@ "+searchTSQuery, tsquery).
+		Where("users.role = ?", model.UserTypeOwner).
+		Order("rank DESC, users.id ASC").
+		Limit(params.Limit).
+		Scan(&results.Owners)
+	if tx.Error != nil {
+		return SearchResults{}, fmt.Errorf("searching owners: %w", tx.Error)
+	}
+
+	pets, err := searchPets(tsquery, params.Limit)
+	if err != nil {
+		return SearchResults{}, fmt.Errorf("searching pets: %w", err)
+	}
+	results.Pets = pets
+
+	if middleware.HasPermission(ctx, model.PermissionVisitNotesRead) {
+		results.VisitNotes = []VisitNoteSearchHit{}
+		tx = initializers.DB.Model(&model.VisitNote{}).
+			Select("visit_notes.id, visit_notes.appointment_id, appointments.pet_id, pets.name AS pet_name, appointments.slot, "+
+				"ts_headline('simple', concat_ws(' ', visit_notes.subjective, visit_notes.objective, visit_notes.assessment, visit_notes.plan), "+searchTSQuery+") AS headline, "+
+				"ts_rank(visit_notes.search_vector, "+searchTSQuery+") AS rank", tsquery, tsquery).
+			Joins("JOIN appointments ON appointments.id = visit_notes.appointment_id AND appointments.deleted_at IS NULL").
+			Joins("JOIN pets ON pets.id = appointments.pet_id AND pets.deleted_at IS NULL").
+			Where("visit_notes.search_vector @@ "+searchTSQuery, tsquery).
+			Order("rank DESC, appointments.slot DESC").
+			Limit(params.Limit).
+			Scan(&results.VisitNotes)
+		if tx.Error != nil {
+			return SearchResults{}, fmt.Errorf("searching visit notes: %w", tx.Error)
+		}
+	}
+	l.Debug().Str("tsquery", tsquery).Int("owners", len(results.Owners)).Int("pets", len(results.Pets)).Msg("Searched")
+	return results, nil
+}
+
+// searchPets ranks the matching pets and then loads them in that order.
+func searchPets(tsquery string, limit int) ([]PetSearchHit, error) {
+	var ranked []struct {
+		ID        uint
+		OwnerName string
+		Rank      float64
+	}
+	err := initializers.DB.Model(&model.Pet{}).
+		Select("pets.id, owners.name AS owner_name, ts_rank(pets.search_vector, "+searchTSQuery+") AS rank", tsquery).
+		Joins("LEFT JOIN users AS owners ON owners.id = pets.owner_id").
+		Where("pets.search_vector @@ "+searchTSQuery, tsquery).
+		Order("rank DESC, pets.id ASC").
+		Limit(limit).
+		Scan(&ranked).Error
+	if err != nil {
+		return nil, err
+	}
+	hits := []PetSearchHit{}
+	if len(ranked) == 0 {
+		return hits, nil
+	}
+	ids := make([]uint, len(ranked))
+	for i, r := range ranked {
+		ids[i] = r.ID
+	}
+	var pets []model.Pet
+	if err := withPetAlerts(initializers.DB, "Alerts").Where("id IN ?", ids).Find(&pets).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]model.Pet, len(pets))
+	for _, pet := range pets {
+		setPetPhotoURLs(&pet)
+		byID[pet.ID] = pet
+	}
+	for _, r := range ranked {
+		hits = append(hits, PetSearchHit{Pet: byID[r.ID], OwnerName: r.OwnerName, Rank: r.Rank})
+	}
+	return hits, nil
+}
+
+// buildSearchQuery turns free text into a tsquery that matches any of its
+// words as a prefix. Only letters, digits and the '@' and '.' of email
+// addresses are kept, so the result is always valid tsquery syntax.
+func buildSearchQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '@' && r != '.'
+	})
+	terms := []string{}
+	for _, word := range words {
+		word = strings.Trim(word, "@.")
+		if word == "" {
+			continue
+		}
+		terms = append(terms, "'"+word+"':*")
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return strings.Join(terms, " | ")
+}
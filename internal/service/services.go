@@ -20,3 +20,122 @@ type UserService struct {
 func NewUserService() *UserService {
 	return &UserService{}
 }
+
+type SessionService struct {
+}
+
+func NewSessionService() *SessionService {
+	return &SessionService{}
+}
+
+type SettingService struct {
+}
+
+func NewSettingService() *SettingService {
+	return &SettingService{}
+}
+
+type RoleService struct {
+}
+
+func NewRoleService() *RoleService {
+	return &RoleService{}
+}
+
+type APIKeyService struct {
+}
+
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{}
+}
+
+type ImpersonationService struct {
+}
+
+func NewImpersonationService() *ImpersonationService {
+	return &ImpersonationService{}
+}
+
+type VaccinationService struct {
+}
+
+func NewVaccinationService() *VaccinationService {
+	return &VaccinationService{}
+}
+
+type VisitNoteService struct {
+}
+
+func NewVisitNoteService() *VisitNoteService {
+	return &VisitNoteService{}
+}
+
+type PrescriptionService struct {
+}
+
+func NewPrescriptionService() *PrescriptionService {
+	return &PrescriptionService{}
+}
+
+type PetAlertService struct {
+}
+
+func NewPetAlertService() *PetAlertService {
+	return &PetAlertService{}
+}
+
+type VitalsService struct {
+}
+
+func NewVitalsService() *VitalsService {
+	return &VitalsService{}
+}
+
+type PetTransferService struct {
+}
+
+func NewPetTransferService() *PetTransferService {
+	return &PetTransferService{}
+}
+
+type HouseholdService struct {
+}
+
+func NewHouseholdService() *HouseholdService {
+	return &HouseholdService{}
+}
+
+type MicrochipService struct {
+}
+
+func NewMicrochipService() *MicrochipService {
+	return &MicrochipService{}
+}
+
+type AuditService struct {
+}
+
+func NewAuditService() *AuditService {
+	return &AuditService{}
+}
+
+type CatalogService struct {
+}
+
+func NewCatalogService() *CatalogService {
+	return &CatalogService{}
+}
+
+type SearchService struct {
+}
+
+func NewSearchService() *SearchService {
+	return &SearchService{}
+}
+
+type DuplicateService struct {
+}
+
+func NewDuplicateService() *DuplicateService {
+	return &DuplicateService{}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+var refreshTokenTTL = durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+
+// AuthTokens is the token pair handed out on login, signup and refresh.
+type AuthTokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// CreateSession starts a new session for the user and returns a fresh
+// access/refresh token pair bound to it.
+func (sessionService *SessionService) CreateSession(user *model.User, ctx context.Context) (AuthTokens, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CreateSession Service")
+	refreshToken, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("creating session: %w", err)
+	}
+	userAgent, _ := ctx.Value(middleware.ContextKeyUserAgent).(string)
+	ipAddress, _ := ctx.Value(middleware.ContextKeyClientIP).(string)
+	now := time.Now()
+	session := model.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(refreshTokenTTL),
+	}
+	if err := initializers.DB.Create(&session).Error; err != nil {
+		return AuthTokens{}, fmt.Errorf("creating session: %w", err)
+	}
+
+	token, err := utils.GenerateJWT(user.ID, session.ID, user.Username, user.Role)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("creating session: %w", err)
+	}
+	return AuthTokens{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// RefreshSession exchanges a refresh token for a new token pair. The refresh
+// token is rotated on every use; presenting an already rotated token revokes
+// the whole session, since it means the token was copied.
+func (sessionService *SessionService) RefreshSession(refreshToken string, ctx context.Context) (AuthTokens, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RefreshSession Service")
+	if refreshToken == "" {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+	hash := utils.HashToken(refreshToken)
+
+	var session model.Session
+	tx := initializers.DB.Preload("User").Where("refresh_token_hash = ?", hash).First(&session)
+	if err := tx.Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return AuthTokens{}, fmt.Errorf("refreshing session: %w", err)
+		}
+		if err := sessionService.revokeReusedToken(hash, ctx); err != nil {
+			return AuthTokens{}, fmt.Errorf("refreshing session: %w", err)
+		}
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) || session.User.Disabled {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("refreshing session %d: %w", session.ID, err)
+	}
+	userAgent, _ := ctx.Value(middleware.ContextKeyUserAgent).(string)
+	ipAddress, _ := ctx.Value(middleware.ContextKeyClientIP).(string)
+
+	// Only rotate if nobody else rotated this token in the meantime.
+	tx = initializers.DB.Model(&model.Session{}).
+		Where("id = ? AND refresh_token_hash = ?", session.ID, hash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  utils.HashToken(newRefreshToken),
+			"previous_token_hash": hash,
+			"user_agent":          userAgent,
+			"ip_address":          ipAddress,
+			"last_used_at":        now,
+			"expires_at":          now.Add(refreshTokenTTL),
+		})
+	if tx.Error != nil {
+		return AuthTokens{}, fmt.Errorf("refreshing session %d: %w", session.ID, tx.Error)
+	}
+	if tx.RowsAffected == 0 {
+		return AuthTokens{}, ErrInvalidRefreshToken
+	}
+
+	token, err := utils.GenerateJWT(session.User.ID, session.ID, session.User.Username, session.User.Role)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("refreshing session %d: %w", session.ID, err)
+	}
+	return AuthTokens{
+		Token:        token,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (sessionService *SessionService) revokeReusedToken(hash string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	tx := initializers.DB.Model(&model.Session{}).
+		Where("previous_token_hash = ? AND revoked_at IS NULL", hash).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected > 0 {
+		l.Warn().Msg("Rotated refresh token was reused, session revoked")
+	}
+	return nil
+}
+
+// RevokeSession ends a single session, e.g. on logout from one device.
+func (sessionService *SessionService) RevokeSession(sessionID, userID uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RevokeSession Service")
+	tx := initializers.DB.Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return fmt.Errorf("revoking session %d: %w", sessionID, tx.Error)
+	}
+	return nil
+}
+
+// RevokeAllSessions logs the user out of every device.
+func (sessionService *SessionService) RevokeAllSessions(userID uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RevokeAllSessions Service")
+	tx := initializers.DB.Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return fmt.Errorf("revoking sessions of user %d: %w", userID, tx.Error)
+	}
+	return nil
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
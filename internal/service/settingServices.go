@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorSettings struct {
+	RequiredForStaff bool `json:"required_for_staff" example:"true"`
+}
+
+func (settingService *SettingService) getSetting(key string) (string, bool, error) {
+	var setting model.Setting
+	tx := initializers.DB.Where("key = ?", key).First(&setting)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return setting.Value, true, nil
+}
+
+func (settingService *SettingService) setSetting(key, value string) error {
+	return initializers.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&model.Setting{Key: key, Value: value}).Error
+}
+
+// GetTwoFactorSettings reports whether users of any role other than owner
+// must use TOTP.
+func (settingService *SettingService) GetTwoFactorSettings(ctx context.Context) (TwoFactorSettings, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetTwoFactorSettings Service")
+	value, ok, err := settingService.getSetting(model.SettingRequireTwoFactor)
+	if err != nil {
+		return TwoFactorSettings{}, fmt.Errorf("getting two-factor settings: %w", err)
+	}
+	if !ok {
+		return TwoFactorSettings{}, nil
+	}
+	required, _ := strconv.ParseBool(value)
+	return TwoFactorSettings{RequiredForStaff: required}, nil
+}
+
+func (settingService *SettingService) UpdateTwoFactorSettings(settings TwoFactorSettings, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateTwoFactorSettings Service")
+	if err := settingService.setSetting(model.SettingRequireTwoFactor, strconv.FormatBool(settings.RequiredForStaff)); err != nil {
+		return fmt.Errorf("updating two-factor settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidSSOState = errors.New("invalid or expired single sign-on state")
+var ErrSSONoRole = errors.New("your identity provider account is not in any group with access to the clinic")
+var ErrSSOAccountConflict = errors.New("a local account with this username or email already exists and cannot be linked")
+var ErrSSOMissingEmail = errors.New("the identity provider did not return an email address")
+var ErrSSORequired = errors.New("this account signs in through single sign-on")
+var ErrSSOLoginFailed = errors.New("the identity provider login could not be verified")
+
+const oidcLoginStateTTL = 10 * time.Minute
+
+// SSOLogin starts a single sign-on attempt. The browser is sent to AuthURL
+// and must keep Binding, e.g. in a cookie, until ExpiresAt: the callback is
+// only accepted together with it, so a login cannot be completed in a
+// browser other than the one that started it.
+type SSOLogin struct {
+	AuthURL   string
+	Binding   string
+	ExpiresAt time.Time
+}
+
+// BeginSSOLogin starts an OIDC authorization code flow and returns the URL of
+// the identity provider's login page.
+func (userService *UserService) BeginSSOLogin(ctx context.Context) (SSOLogin, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside BeginSSOLogin Service")
+	provider, err := oidc.Get()
+	if err != nil {
+		return SSOLogin{}, err
+	}
+	state, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return SSOLogin{}, fmt.Errorf("starting single sign-on: %w", err)
+	}
+	nonce, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return SSOLogin{}, fmt.Errorf("starting single sign-on: %w", err)
+	}
+	verifier, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return SSOLogin{}, fmt.Errorf("starting single sign-on: %w", err)
+	}
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return SSOLogin{}, fmt.Errorf("starting single sign-on: %w", err)
+	}
+	loginState := model.OIDCLoginState{
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(oidcLoginStateTTL),
+	}
+	if err := initializers.DB.Create(&loginState).Error; err != nil {
+		return SSOLogin{}, fmt.Errorf("starting single sign-on: %w", err)
+	}
+	// Opaque tokens are base64url, so "." cannot occur in either part.
+	return SSOLogin{AuthURL: authURL, Binding: state + "." + nonce, ExpiresAt: loginState.ExpiresAt}, nil
+}
+
+// CompleteSSOLogin handles the identity provider's callback. binding is the
+// value BeginSSOLogin handed to the browser; it must carry the same state
+// and the nonce of that login. It creates the local user on first login,
+// keeps its role in sync with the IdP groups and starts a session.
+// Two-factor authentication is left to the IdP.
+func (userService *UserService) CompleteSSOLogin(code, state, binding string, ctx context.Context) (AuthTokens, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CompleteSSOLogin Service")
+	provider, err := oidc.Get()
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	if code == "" || state == "" {
+		return AuthTokens{}, ErrInvalidSSOState
+	}
+	boundState, boundNonce, _ := strings.Cut(binding, ".")
+	if subtle.ConstantTimeCompare([]byte(boundState), []byte(state)) != 1 {
+		l.Warn().Msg("Single sign-on callback does not come from the browser that started the login")
+		return AuthTokens{}, ErrInvalidSSOState
+	}
+
+	var loginState model.OIDCLoginState
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("state_hash = ? AND used_at IS NULL AND expires_at > ?", utils.HashToken(state), time.Now()).First(&loginState)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return ErrInvalidSSOState
+			}
+			return result.Error
+		}
+		if subtle.ConstantTimeCompare([]byte(boundNonce), []byte(loginState.Nonce)) != 1 {
+			return ErrInvalidSSOState
+		}
+		return tx.Model(&loginState).Update("used_at", time.Now()).Error
+	})
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("completing single sign-on: %w", err)
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("completing single sign-on: %w: %w", ErrSSOLoginFailed, err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("completing single sign-on: %w: %w", ErrSSOLoginFailed, err)
+	}
+
+	role, ok := provider.MapRole(claims.Groups)
+	if !ok || role == model.UserTypeOwner {
+		l.Warn().Str("sub", claims.Subject).Strs("groups", claims.Groups).Msg("Single sign-on user has no mapped role")
+		return AuthTokens{}, ErrSSONoRole
+	}
+	if err := validateRoleExists(role); err != nil {
+		return AuthTokens{}, fmt.Errorf("completing single sign-on: role %q from OIDC_ROLE_MAP: %w", role, err)
+	}
+
+	user, err := userService.ssoUser(claims, role, ctx)
+	if err != nil {
+		return AuthTokens{}, fmt.Errorf("completing single sign-on: %w", err)
+	}
+	if user.Disabled {
+		return AuthTokens{}, ErrAccountDisabled
+	}
+	l.Info().Uint("userID", user.ID).Str("role", user.Role).Msg("User signed in through single sign-on")
+	sessionService := &SessionService{}
+	return sessionService.CreateSession(&user, ctx)
+}
+
+// ssoUser finds the local user for an IdP subject. A staff account with the
+// same verified email is linked on first login; otherwise a new user is
+// created. The role follows the IdP groups, and a role change revokes the
+// user's other sessions just like ChangeUserRole does.
+func (userService *UserService) ssoUser(claims oidc.Claims, role string, ctx context.Context) (model.User, error) {
+	l := zerolog.Ctx(ctx)
+	var user model.User
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("oidc_subject = ?", claims.Subject).First(&user)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return result.Error
+		}
+		if result.Error == nil {
+			if user.Role == role {
+				return nil
+			}
+			l.Info().Uint("userID", user.ID).Str("from", user.Role).Str("to", role).Msg("Syncing role from identity provider")
+			if err := tx.Model(&user).Update("role", role).Error; err != nil {
+				return err
+			}
+			return tx.Model(&model.Session{}).
+				Where("user_id = ? AND revoked_at IS NULL", user.ID).
+				Update("revoked_at", time.Now()).Error
+		}
+
+		if claims.Email == "" {
+			return ErrSSOMissingEmail
+		}
+		result = tx.Where("LOWER(email) = LOWER(?)", claims.Email).First(&user)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return result.Error
+		}
+		if result.Error == nil {
+			// Linking by email is only safe when the IdP vouches for the
+			// address, and owner accounts never move to SSO.
+			if !claims.EmailVerified || user.OIDCSubject != nil || user.Role == model.UserTypeOwner {
+				return ErrSSOAccountConflict
+			}
+			l.Info().Uint("userID", user.ID).Msg("Linking existing account to identity provider")
+			user.OIDCSubject = &claims.Subject
+			user.Role = role
+			updates := map[string]interface{}{"oidc_subject": claims.Subject, "role": role}
+			if err := tx.Model(&user).Updates(updates).Error; err != nil {
+				return err
+			}
+			return tx.Model(&model.Session{}).
+				Where("user_id = ? AND revoked_at IS NULL", user.ID).
+				Update("revoked_at", time.Now()).Error
+		}
+
+		username := claims.PreferredUsername
+		if username == "" {
+			username = claims.Email
+		}
+		var taken int64
+		if err := tx.Model(&model.User{}).Where("username = ?", username).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrSSOAccountConflict
+		}
+		name := strings.TrimSpace(claims.Name)
+		if name == "" {
+			name = username
+		}
+		// SSO users have no local password; an empty hash never matches.
+		user = model.User{
+			Username:    username,
+			Role:        role,
+			Name:        name,
+			Email:       claims.Email,
+			OIDCSubject: &claims.Subject,
+		}
+		if claims.EmailVerified {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+		l.Info().Str("username", username).Str("role", role).Msg("Creating user from identity provider")
+		return tx.Create(&user).Error
+	})
+	if err != nil {
+		return model.User{}, err
+	}
+	user.Password = ""
+	return user, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/oidc/oidctest"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// useMockIdP points single sign-on at a fresh mock provider that logs users
+// in with claims and maps the clinic-staff group to the staff role.
+func useMockIdP(t *testing.T, claims jwt.MapClaims) *oidctest.Server {
+	t.Helper()
+	server := oidctest.NewServer("pet-clinic", claims)
+	t.Cleanup(server.Close)
+	oidc.Set(oidc.NewProvider(oidc.Config{
+		Issuer:      server.URL,
+		ClientID:    "pet-clinic",
+		RedirectURL: "http://localhost:8000/sso/oidc/callback",
+		Scopes:      []string{"openid", "profile", "email"},
+		GroupsClaim: "groups",
+		RoleMap:     oidc.ParseRoleMap("clinic-staff=staff"),
+	}))
+	return server
+}
+
+// useTestDB connects to the database in TEST_DATABASE_DSN, migrates it and
+// loads a throwaway JWT signing key. Tests that need it are skipped when the
+// variable is not set.
+func useTestDB(t *testing.T) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("connecting to the test database: %v", err)
+	}
+	initializers.DB = db
+	if err := initializers.MigrateDB(); err != nil {
+		t.Fatalf("migrating the test database: %v", err)
+	}
+	if err := NewRoleService().SeedRoles(context.Background()); err != nil {
+		t.Fatalf("seeding roles: %v", err)
+	}
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := utils.LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("loading the test signing key: %v", err)
+	}
+	utils.SetKeySet(keys)
+}
+
+func TestCompleteSSOLoginRejectsBadState(t *testing.T) {
+	useMockIdP(t, jwt.MapClaims{"sub": "idp-user"})
+	userService := NewUserService()
+	for name, binding := range map[string]string{
+		"missing cookie": "",
+		"other state":    "another-state.nonce",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := userService.CompleteSSOLogin("code", "state", binding, context.Background())
+			if !errors.Is(err, ErrInvalidSSOState) {
+				t.Fatalf("CompleteSSOLogin error = %v, want ErrInvalidSSOState", err)
+			}
+		})
+	}
+}
+
+func TestCompleteSSOLoginCreatesUser(t *testing.T) {
+	useTestDB(t)
+	suffix, err := utils.GenerateOpaqueToken(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := useMockIdP(t, jwt.MapClaims{
+		"sub":                "idp-" + suffix,
+		"email":              "vet-" + suffix + "@clinic.test",
+		"email_verified":     true,
+		"name":               "Jane Vet",
+		"preferred_username": "vet-" + suffix,
+		"groups":             []string{"clinic-staff"},
+	})
+	ctx := context.Background()
+	userService := NewUserService()
+
+	login, err := userService.BeginSSOLogin(ctx)
+	if err != nil {
+		t.Fatalf("BeginSSOLogin: %v", err)
+	}
+	code, state, err := server.Login(login.AuthURL)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	tokens, err := userService.CompleteSSOLogin(code, state, login.Binding, ctx)
+	if err != nil {
+		t.Fatalf("CompleteSSOLogin: %v", err)
+	}
+	if tokens.Token == "" || tokens.RefreshToken == "" {
+		t.Errorf("CompleteSSOLogin returned empty tokens %+v", tokens)
+	}
+
+	var user model.User
+	if err := initializers.DB.Where("oidc_subject = ?", "idp-"+suffix).First(&user).Error; err != nil {
+		t.Fatalf("loading the created user: %v", err)
+	}
+	if user.Role != model.UserTypeStaff || user.Username != "vet-"+suffix || user.EmailVerifiedAt == nil {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	// The state is single use, so replaying the callback must fail.
+	if _, err := userService.CompleteSSOLogin(code, state, login.Binding, ctx); !errors.Is(err, ErrInvalidSSOState) {
+		t.Errorf("replayed CompleteSSOLogin error = %v, want ErrInvalidSSOState", err)
+	}
+}
+
+func TestCompleteSSOLoginRejectsOtherBrowser(t *testing.T) {
+	useTestDB(t)
+	server := useMockIdP(t, jwt.MapClaims{"sub": "idp-other-browser", "groups": []string{"clinic-staff"}})
+	ctx := context.Background()
+	userService := NewUserService()
+
+	victim, err := userService.BeginSSOLogin(ctx)
+	if err != nil {
+		t.Fatalf("BeginSSOLogin: %v", err)
+	}
+	attacker, err := userService.BeginSSOLogin(ctx)
+	if err != nil {
+		t.Fatalf("BeginSSOLogin: %v", err)
+	}
+	code, state, err := server.Login(attacker.AuthURL)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := userService.CompleteSSOLogin(code, state, victim.Binding, ctx); !errors.Is(err, ErrInvalidSSOState) {
+		t.Fatalf("CompleteSSOLogin error = %v, want ErrInvalidSSOState", err)
+	}
+	// A cookie forged from the callback's state still lacks the nonce.
+	_, victimNonce, _ := strings.Cut(victim.Binding, ".")
+	if _, err := userService.CompleteSSOLogin(code, state, state+"."+victimNonce, ctx); !errors.Is(err, ErrInvalidSSOState) {
+		t.Fatalf("CompleteSSOLogin with a forged cookie error = %v, want ErrInvalidSSOState", err)
+	}
+}
@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+var ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+var ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+var ErrTwoFactorEnrollmentNotStarted = errors.New("two-factor enrollment has not been started")
+var ErrTwoFactorMandatory = errors.New("two-factor authentication is mandatory for this role")
+
+const (
+	twoFactorChallengeTTL         = 5 * time.Minute
+	twoFactorChallengeMaxAttempts = 5
+	recoveryCodeCount             = 10
+)
+
+// TwoFactorRequiredError is returned by Login when the password was correct
+// but a second step is needed. The challenge token identifies the pending
+// login in that second step.
+type TwoFactorRequiredError struct {
+	ChallengeToken     string
+	EnrollmentRequired bool
+}
+
+func (e TwoFactorRequiredError) Error() string {
+	if e.EnrollmentRequired {
+		return "two-factor enrollment required"
+	}
+	return "two-factor authentication required"
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Pet%20Clinic:jane.vet?algorithm=SHA1&digits=6&issuer=Pet+Clinic&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+type TwoFactorLoginResult struct {
+	AuthTokens
+	// RecoveryCodes is only set when the login also completed an enrollment.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Pet Clinic"
+}
+
+// twoFactorRequiredFor reports whether the user's role must use TOTP. The
+// clinic setting covers every role except owner.
+func (userService *UserService) twoFactorRequiredFor(user *model.User, ctx context.Context) (bool, error) {
+	if user.Role == model.UserTypeOwner {
+		return false, nil
+	}
+	settingService := &SettingService{}
+	settings, err := settingService.GetTwoFactorSettings(ctx)
+	if err != nil {
+		return false, err
+	}
+	return settings.RequiredForStaff, nil
+}
+
+// startTwoFactorChallenge returns a TwoFactorRequiredError if the user has to
+// pass a second step before getting tokens, and nil otherwise.
+func (userService *UserService) startTwoFactorChallenge(user *model.User, ctx context.Context) error {
+	enrollmentRequired := false
+	if !user.TOTPEnabled {
+		required, err := userService.twoFactorRequiredFor(user, ctx)
+		if err != nil {
+			return err
+		}
+		if !required {
+			return nil
+		}
+		enrollmentRequired = true
+	}
+	token, err := userService.issueUserToken(user.ID, model.TokenPurposeTwoFactorLogin, twoFactorChallengeTTL)
+	if err != nil {
+		return err
+	}
+	return TwoFactorRequiredError{ChallengeToken: token, EnrollmentRequired: enrollmentRequired}
+}
+
+// BeginTwoFactorEnrollment generates a new TOTP secret for the user. It only
+// takes effect once confirmed with a valid code.
+func (userService *UserService) BeginTwoFactorEnrollment(userID uint, ctx context.Context) (TwoFactorEnrollment, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside BeginTwoFactorEnrollment Service")
+	var user model.User
+	if err := initializers.DB.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return TwoFactorEnrollment{}, UserNotFoundError{ID: userID}
+		}
+		return TwoFactorEnrollment{}, fmt.Errorf("beginning two-factor enrollment for user %d: %w", userID, err)
+	}
+	enrollment, err := userService.beginEnrollment(&user)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("beginning two-factor enrollment for user %d: %w", userID, err)
+	}
+	return enrollment, nil
+}
+
+// BeginChallengeEnrollment starts enrollment for a user whose login is
+// blocked until they set up TOTP.
+func (userService *UserService) BeginChallengeEnrollment(challengeToken string, ctx context.Context) (TwoFactorEnrollment, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside BeginChallengeEnrollment Service")
+	challenge, err := userService.lookupUserToken(initializers.DB, challengeToken, model.TokenPurposeTwoFactorLogin)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("beginning two-factor enrollment: %w", err)
+	}
+	var user model.User
+	if err := initializers.DB.First(&user, challenge.UserID).Error; err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("beginning two-factor enrollment: %w", err)
+	}
+	enrollment, err := userService.beginEnrollment(&user)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("beginning two-factor enrollment: %w", err)
+	}
+	return enrollment, nil
+}
+
+func (userService *UserService) beginEnrollment(user *model.User) (TwoFactorEnrollment, error) {
+	if user.TOTPEnabled {
+		return TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+	}
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	if err := initializers.DB.Model(user).Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0}).Error; err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	return TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(totpIssuer(), user.Username, secret),
+	}, nil
+}
+
+// ConfirmTwoFactorEnrollment enables TOTP once the user proves their
+// authenticator works, and returns fresh recovery codes.
+func (userService *UserService) ConfirmTwoFactorEnrollment(userID uint, code string, ctx context.Context) ([]string, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ConfirmTwoFactorEnrollment Service")
+	var codes []string
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return UserNotFoundError{ID: userID}
+			}
+			return err
+		}
+		var err error
+		codes, err = userService.enableTwoFactor(tx, &user, code)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("confirming two-factor enrollment for user %d: %w", userID, err)
+	}
+	return codes, nil
+}
+
+func (userService *UserService) enableTwoFactor(tx *gorm.DB, user *model.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorEnrollmentNotStarted
+	}
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	if err := tx.Model(user).Updates(map[string]interface{}{"totp_enabled": true, "totp_last_step": step}).Error; err != nil {
+		return nil, err
+	}
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	return userService.replaceRecoveryCodes(tx, user.ID)
+}
+
+// DisableTwoFactor turns TOTP off after checking the password and a current
+// code. It is refused when TOTP is mandatory for the user's role.
+func (userService *UserService) DisableTwoFactor(userID uint, password, code string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DisableTwoFactor Service")
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return UserNotFoundError{ID: userID}
+			}
+			return err
+		}
+		if !user.TOTPEnabled {
+			return ErrTwoFactorNotEnabled
+		}
+		if !utils.CheckPasswordHash(password, user.Password) {
+			return ErrInvalidCredentials
+		}
+		if required, err := userService.twoFactorRequiredFor(&user, ctx); err != nil {
+			return err
+		} else if required {
+			return ErrTwoFactorMandatory
+		}
+		if err := userService.verifySecondFactor(tx, &user, code, ""); err != nil {
+			return err
+		}
+		return userService.clearTwoFactor(tx, user.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("disabling two-factor for user %d: %w", userID, err)
+	}
+	return nil
+}
+
+// ResetTwoFactor lets an admin clear the TOTP setup of a user who lost their
+// authenticator and recovery codes. The user's sessions are revoked.
+func (userService *UserService) ResetTwoFactor(userID uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ResetTwoFactor Service")
+	user, err := userService.GetUser(userID, ctx)
+	if err != nil {
+		return fmt.Errorf("resetting two-factor for user %d: %w", userID, err)
+	}
+	if err := ensureCanGrantRole(user.Role, ctx); err != nil {
+		return fmt.Errorf("resetting two-factor for user %d: %w", userID, err)
+	}
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := userService.clearTwoFactor(tx, userID); err != nil {
+			return err
+		}
+		return tx.Model(&model.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
+		return fmt.Errorf("resetting two-factor for user %d: %w", userID, err)
+	}
+	return nil
+}
+
+func (userService *UserService) clearTwoFactor(tx *gorm.DB, userID uint) error {
+	if err := tx.Model(&model.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"totp_enabled": false, "totp_secret": "", "totp_last_step": 0}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user after
+// checking a current TOTP code.
+func (userService *UserService) RegenerateRecoveryCodes(userID uint, code string, ctx context.Context) ([]string, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RegenerateRecoveryCodes Service")
+	var codes []string
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.First(&user, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return UserNotFoundError{ID: userID}
+			}
+			return err
+		}
+		if !user.TOTPEnabled {
+			return ErrTwoFactorNotEnabled
+		}
+		if err := userService.verifySecondFactor(tx, &user, code, ""); err != nil {
+			return err
+		}
+		var err error
+		codes, err = userService.replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("regenerating recovery codes for user %d: %w", userID, err)
+	}
+	return codes, nil
+}
+
+func (userService *UserService) replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, recoveryCodeCount)
+	rows := make([]model.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.GenerateTOTPSecret()
+		if err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(raw[:5] + "-" + raw[5:10])
+		hash, err := utils.HashPassword(normalizeRecoveryCode(code))
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		rows = append(rows, model.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// verifySecondFactor accepts either a TOTP code, which may not be replayed,
+// or an unused recovery code, which is then spent.
+func (userService *UserService) verifySecondFactor(tx *gorm.DB, user *model.User, code, recoveryCode string) error {
+	if code != "" {
+		step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+		if !ok || step <= user.TOTPLastStep {
+			return ErrInvalidTwoFactorCode
+		}
+		result := tx.Model(&model.User{}).
+			Where("id = ? AND totp_last_step < ?", user.ID, step).
+			Update("totp_last_step", step)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		user.TOTPLastStep = step
+		return nil
+	}
+
+	if recoveryCode == "" {
+		return ErrInvalidTwoFactorCode
+	}
+	var recoveryCodes []model.RecoveryCode
+	if err := tx.Where("user_id = ? AND used_at IS NULL", user.ID).Find(&recoveryCodes).Error; err != nil {
+		return err
+	}
+	normalized := normalizeRecoveryCode(recoveryCode)
+	for _, rc := range recoveryCodes {
+		if !utils.CheckPasswordHash(normalized, rc.CodeHash) {
+			continue
+		}
+		result := tx.Model(&model.RecoveryCode{}).
+			Where("id = ? AND used_at IS NULL", rc.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+// CompleteTwoFactorLogin finishes a login that Login answered with a
+// TwoFactorRequiredError. For users that still have to enroll, the code
+// confirms the enrollment and the new recovery codes are returned as well.
+func (userService *UserService) CompleteTwoFactorLogin(challengeToken, code, recoveryCode string, ctx context.Context) (TwoFactorLoginResult, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside CompleteTwoFactorLogin Service")
+	challenge, err := userService.lookupUserToken(initializers.DB, challengeToken, model.TokenPurposeTwoFactorLogin)
+	if err != nil {
+		return TwoFactorLoginResult{}, fmt.Errorf("completing two-factor login: %w", err)
+	}
+
+	var user model.User
+	var recoveryCodes []string
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&user, challenge.UserID).Error; err != nil {
+			return err
+		}
+		if user.Disabled {
+			return ErrAccountDisabled
+		}
+		if user.TOTPEnabled {
+			if err := userService.verifySecondFactor(tx, &user, code, recoveryCode); err != nil {
+				return err
+			}
+		} else {
+			var err error
+			if recoveryCodes, err = userService.enableTwoFactor(tx, &user, code); err != nil {
+				return err
+			}
+		}
+		return userService.markUserTokenUsed(tx, &challenge)
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			userService.recordFailedChallenge(&challenge, ctx)
+		}
+		return TwoFactorLoginResult{}, fmt.Errorf("completing two-factor login: %w", err)
+	}
+
+	sessionService := &SessionService{}
+	tokens, err := sessionService.CreateSession(&user, ctx)
+	if err != nil {
+		return TwoFactorLoginResult{}, fmt.Errorf("completing two-factor login: %w", err)
+	}
+	return TwoFactorLoginResult{AuthTokens: tokens, RecoveryCodes: recoveryCodes}, nil
+}
+
+// recordFailedChallenge counts a wrong code and burns the challenge after too
+// many attempts, so codes cannot be guessed within its lifetime.
+func (userService *UserService) recordFailedChallenge(challenge *model.UserToken, ctx context.Context) {
+	l := zerolog.Ctx(ctx)
+	updates := map[string]interface{}{"attempts": gorm.Expr("attempts + 1")}
+	if challenge.Attempts+1 >= twoFactorChallengeMaxAttempts {
+		updates["used_at"] = time.Now()
+	}
+	if err := initializers.DB.Model(&model.UserToken{}).Where("id = ?", challenge.ID).Updates(updates).Error; err != nil {
+		l.Error().Err(err).Uint("challengeID", challenge.ID).Msg("Failed to record failed two-factor attempt")
+	}
+}
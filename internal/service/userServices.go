@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/lockout"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/validators"
@@ -24,7 +27,7 @@ func (e UserNotFoundError) Error() string {
 
 var ErrInvalidCredentials = errors.New("invalid credentials")
 var ErrInvalidUserInput = errors.New("all fields are required")
-var ErrPasswordTooShort = errors.New("password must be at least 8 characters long")
+var ErrAccountDisabled = errors.New("account is disabled")
 
 type UserSignupParams struct {
 	Username string `json:"username"`
@@ -34,25 +37,61 @@ type UserSignupParams struct {
 	Contact  string `json:"contact"`
 }
 
-func (userService *UserService) Login(username, password string) (string, error) {
+// dummyPasswordHash is compared against when the username does not exist so
+// that a failed login takes as long as one with a wrong password.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.HashPassword("not-a-real-password")
+	return hash
+})
+
+func (userService *UserService) Login(username, password string, ctx context.Context) (AuthTokens, error) {
+	l := zerolog.Ctx(ctx)
 	user := &model.User{Username: username, Password: password}
 	if user.Username == "" || user.Password == "" {
-		return "", ErrInvalidUserInput
+		return AuthTokens{}, ErrInvalidUserInput
+	}
+	clientIP, _ := ctx.Value(middleware.ContextKeyClientIP).(string)
+	limiter := lockout.Get()
+	if err := limiter.Check(ctx, username, clientIP); err != nil {
+		return AuthTokens{}, fmt.Errorf("logging in %q: %w", username, err)
+	}
+	// Unknown usernames and wrong passwords must look the same to the
+	// caller, including in how long the bcrypt comparison takes.
+	tx := initializers.DB.Where("username = ?", user.Username).First(user)
+	if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return AuthTokens{}, fmt.Errorf("logging in %q: %w", username, tx.Error)
+	}
+	hash := user.Password
+	if tx.Error != nil {
+		hash = dummyPasswordHash()
+	}
+	if !utils.CheckPasswordHash(password, hash) || tx.Error != nil {
+		l.Warn().Str("username", username).Str("clientIP", clientIP).Msg("Failed login attempt")
+		if err := limiter.RecordFailure(ctx, username, clientIP); err != nil {
+			l.Error().Err(err).Msg("Failed to record failed login attempt")
+		}
+		return AuthTokens{}, ErrInvalidCredentials
 	}
-	if tx := initializers.DB.Where("username = ?", user.Username).First(user); tx.Error != nil {
-		return "", tx.Error
+	if err := limiter.RecordSuccess(ctx, username); err != nil {
+		l.Error().Err(err).Msg("Failed to reset failed login attempts")
 	}
-	if !utils.CheckPasswordHash(password, user.Password) {
-		return "", ErrInvalidCredentials
+	if user.OIDCSubject != nil {
+		return AuthTokens{}, ErrSSORequired
 	}
-	token, err := utils.GenerateJWT(user.ID, user.Username, user.Role)
-	if err != nil {
-		return "", err
+	if user.Disabled {
+		return AuthTokens{}, ErrAccountDisabled
 	}
-	return token, nil
+	if requireEmailVerification && user.EmailVerifiedAt == nil {
+		return AuthTokens{}, ErrEmailNotVerified
+	}
+	if err := userService.startTwoFactorChallenge(user, ctx); err != nil {
+		return AuthTokens{}, err
+	}
+	sessionService := &SessionService{}
+	return sessionService.CreateSession(user, ctx)
 }
 
-func (userService *UserService) Signup(userParams *UserSignupParams) (string, error) {
+func (userService *UserService) Signup(userParams *UserSignupParams, ctx context.Context) (AuthTokens, error) {
 	user := &model.User{
 		Name:     userParams.Name,
 		Email:    userParams.Email,
@@ -61,41 +100,60 @@ func (userService *UserService) Signup(userParams *UserSignupParams) (string, er
 		Password: userParams.Password,
 		Role:     model.UserTypeOwner,
 	}
-	if user.Username == "" || user.Password == "" || user.Name == "" || user.Email == "" || user.Contact == "" {
-		return "", ErrInvalidUserInput
+	if err := userService.createUser(user); err != nil {
+		return AuthTokens{}, err
 	}
-	if len(user.Password) < 8 {
-		return "", ErrPasswordTooShort
+
+	if err := userService.SendVerificationEmail(user, ctx); err != nil {
+		l := zerolog.Ctx(ctx)
+		l.Error().Err(err).Uint("userID", user.ID).Msg("Failed to send verification email")
 	}
-	if tx := initializers.DB.Where("username = ?", user.Username).First(&model.User{}); tx.Error == nil {
-		return "", ErrInvalidCredentials
+	if requireEmailVerification {
+		return AuthTokens{}, nil
 	}
 
-	hashedPassword, err := utils.HashPassword(user.Password)
+	sessionService := &SessionService{}
+	return sessionService.CreateSession(user, ctx)
+}
+
+// createUser validates a new user, hashes its password and stores it.
+// The caller decides the role.
+func (userService *UserService) createUser(user *model.User) error {
+	if user.Username == "" || user.Password == "" || user.Name == "" || user.Email == "" || user.Contact == "" {
+		return ErrInvalidUserInput
+	}
+	email, err := validators.NormalizeEmail(user.Email)
 	if err != nil {
-		return "", err
+		return err
 	}
-	user.Password = hashedPassword
-
-	if err := initializers.DB.Create(user).Error; err != nil {
-		return "", err
+	user.Email = email
+	if err := validators.ValidatePassword(user.Password); err != nil {
+		return err
+	}
+	if tx := initializers.DB.Where("username = ?", user.Username).First(&model.User{}); tx.Error == nil {
+		return ErrInvalidCredentials
 	}
 
-	token, err := utils.GenerateJWT(user.ID, user.Username, user.Role)
+	hashedPassword, err := utils.HashPassword(user.Password)
 	if err != nil {
-		return "", err
+		return err
 	}
+	user.Password = hashedPassword
 
-	return token, nil
+	return initializers.DB.Create(user).Error
 }
 
+// GetUser returns a user's own profile. Anyone with owners:read may also
+// read owner accounts, and users:manage allows reading any account.
 func (userService *UserService) GetUser(id uint, ctx context.Context) (model.User, error) {
 	l := zerolog.Ctx(ctx)
 	l.Trace().Msg("Inside GetUser Service")
 	var user model.User
-	tx := initializers.DB.
-		Preload("Pets").
-		First(&user, id)
+	query := initializers.DB.Preload("Pets")
+	if middleware.HasPermission(ctx, model.PermissionPetsReadAll) {
+		query = withPetAlerts(query, "Pets.Alerts")
+	}
+	tx := query.First(&user, id)
 	if err := tx.Error; err != nil {
 		switch err {
 		case gorm.ErrRecordNotFound:
@@ -107,7 +165,10 @@ func (userService *UserService) GetUser(id uint, ctx context.Context) (model.Use
 
 	user.Password = ""
 
-	if err := validators.ValidateResourceOwner(user.ID, ctx); err != nil {
+	if user.Role == model.UserTypeOwner && middleware.HasPermission(ctx, model.PermissionOwnersRead) {
+		return user, nil
+	}
+	if err := validators.ValidateResourceOwner(validators.Owner{UserID: user.ID}, "", model.PermissionUsersManage, ctx); err != nil {
 		return model.User{}, fmt.Errorf("getting user %d: %w", id, err)
 	}
 
@@ -121,12 +182,24 @@ func (userService *UserService) UpdateUser(id uint, user *model.User, ctx contex
 	if err != nil {
 		return fmt.Errorf("updating user %d: %w", id, err)
 	}
+	// Reading an owner's profile does not allow changing it.
+	if err := validators.ValidateResourceOwner(validators.Owner{UserID: id}, "", model.PermissionUsersManage, ctx); err != nil {
+		return fmt.Errorf("updating user %d: %w", id, err)
+	}
 
 	if user.Name != "" {
 		existingUser.Name = user.Name
 	}
+	emailChanged := false
 	if user.Email != "" {
+		if user.Email, err = validators.NormalizeEmail(user.Email); err != nil {
+			return err
+		}
+	}
+	if user.Email != "" && user.Email != existingUser.Email {
 		existingUser.Email = user.Email
+		existingUser.EmailVerifiedAt = nil
+		emailChanged = true
 	}
 	if user.Contact != "" {
 		existingUser.Contact = user.Contact
@@ -142,6 +215,15 @@ func (userService *UserService) UpdateUser(id uint, user *model.User, ctx contex
 	if err := tx.Error; err != nil {
 		return fmt.Errorf("updating user %d: %w", id, err)
 	}
+	if emailChanged {
+		// Updates skips nil fields, so clear the verification explicitly.
+		if err := initializers.DB.Model(&existingUser).Update("email_verified_at", nil).Error; err != nil {
+			return fmt.Errorf("updating user %d: %w", id, err)
+		}
+		if err := userService.SendVerificationEmail(&existingUser, ctx); err != nil {
+			l.Error().Err(err).Uint("userID", id).Msg("Failed to send verification email")
+		}
+	}
 	*user = existingUser
 	return nil
 }
@@ -153,6 +235,9 @@ func (userService *UserService) DeleteUser(id uint, ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("deleting user %d: %w", id, err)
 	}
+	if err := validators.ValidateResourceOwner(validators.Owner{UserID: id}, "", model.PermissionUsersManage, ctx); err != nil {
+		return fmt.Errorf("deleting user %d: %w", id, err)
+	}
 
 	tx := initializers.DB.Delete(&existingUser)
 	if err := tx.Error; err != nil {
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/mailer"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/utils"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidUserToken = errors.New("invalid or expired token")
+var ErrEmailNotVerified = errors.New("email address is not verified")
+var ErrEmailAlreadyVerified = errors.New("email address is already verified")
+
+const (
+	emailVerificationTTL = 48 * time.Hour
+	passwordResetTTL     = time.Hour
+
+	verificationResendInterval = 5 * time.Minute
+)
+
+// requireEmailVerification makes Login refuse accounts whose email address
+// has not been verified yet.
+var requireEmailVerification = os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true"
+
+// frontendURL is where the links in verification and reset mails point. The
+// front end reads the token from the link and posts it to /verify-email or
+// /password/reset. APP_BASE_URL is still honoured for older deployments.
+func frontendURL() string {
+	for _, name := range []string{"FRONTEND_URL", "APP_BASE_URL"} {
+		if url := os.Getenv(name); url != "" {
+			return strings.TrimSuffix(url, "/")
+		}
+	}
+	return "http://localhost:3000"
+}
+
+// issueUserToken creates a new single-use token for the user. Earlier unused
+// tokens with the same purpose are invalidated so only the latest mail works.
+func (userService *UserService) issueUserToken(userID uint, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateOpaqueToken(32)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.UserToken{
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: now.Add(ttl),
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// lookupUserToken returns the token if it exists, is unused and has not
+// expired, without redeeming it.
+func (userService *UserService) lookupUserToken(tx *gorm.DB, token, purpose string) (model.UserToken, error) {
+	if token == "" {
+		return model.UserToken{}, ErrInvalidUserToken
+	}
+	var userToken model.UserToken
+	err := tx.Where("token_hash = ? AND purpose = ?", utils.HashToken(token), purpose).First(&userToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.UserToken{}, ErrInvalidUserToken
+		}
+		return model.UserToken{}, err
+	}
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		return model.UserToken{}, ErrInvalidUserToken
+	}
+	return userToken, nil
+}
+
+// markUserTokenUsed redeems a token. The update only succeeds once, so a
+// token cannot be redeemed twice even concurrently.
+func (userService *UserService) markUserTokenUsed(tx *gorm.DB, userToken *model.UserToken) error {
+	now := time.Now()
+	result := tx.Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", userToken.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidUserToken
+	}
+	userToken.UsedAt = &now
+	return nil
+}
+
+// consumeUserToken looks up a token and redeems it in one step.
+func (userService *UserService) consumeUserToken(tx *gorm.DB, token, purpose string) (model.UserToken, error) {
+	userToken, err := userService.lookupUserToken(tx, token, purpose)
+	if err != nil {
+		return model.UserToken{}, err
+	}
+	if err := userService.markUserTokenUsed(tx, &userToken); err != nil {
+		return model.UserToken{}, err
+	}
+	return userToken, nil
+}
+
+// SendVerificationEmail mails the user a link to confirm their email address.
+func (userService *UserService) SendVerificationEmail(user *model.User, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SendVerificationEmail Service")
+	token, err := userService.issueUserToken(user.ID, model.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return fmt.Errorf("sending verification email to user %d: %w", user.ID, err)
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\nPlease confirm your email address by opening the link below:\n\n%s/verify-email?token=%s\n\nThe link expires in %d hours.\n",
+			user.Name, frontendURL(), token, int(emailVerificationTTL.Hours())),
+	}
+	if err := mailer.Get().Send(ctx, msg); err != nil {
+		return fmt.Errorf("sending verification email to user %d: %w", user.ID, err)
+	}
+	return nil
+}
+
+// ResendVerificationEmail sends a fresh verification link if an unverified
+// account uses the email address. Like RequestPasswordReset it never reports
+// whether the address is known, and it sends at most one mail per account
+// every verificationResendInterval.
+func (userService *UserService) ResendVerificationEmail(email string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ResendVerificationEmail Service")
+	if email == "" {
+		return ErrInvalidUserInput
+	}
+	var user model.User
+	tx := initializers.DB.Where("LOWER(email) = LOWER(?)", email).First(&user)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			l.Debug().Msg("Verification email requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("resending verification email: %w", err)
+	}
+	if user.EmailVerifiedAt != nil || user.Disabled {
+		l.Debug().Uint("userID", user.ID).Msg("Verification email requested for verified or disabled account")
+		return nil
+	}
+
+	var recent int64
+	tx = initializers.DB.Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at > ?", user.ID, model.TokenPurposeEmailVerification, time.Now().Add(-verificationResendInterval)).
+		Count(&recent)
+	if tx.Error != nil {
+		return fmt.Errorf("resending verification email: %w", tx.Error)
+	}
+	if recent > 0 {
+		l.Debug().Uint("userID", user.ID).Msg("Verification email requested again too soon")
+		return nil
+	}
+	return userService.SendVerificationEmail(&user, ctx)
+}
+
+// VerifyEmail redeems a verification token and marks the email as verified.
+func (userService *UserService) VerifyEmail(token string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside VerifyEmail Service")
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		userToken, err := userService.consumeUserToken(tx, token, model.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).
+			Where("id = ?", userToken.UserID).
+			Update("email_verified_at", time.Now()).Error
+	})
+	if err != nil {
+		return fmt.Errorf("verifying email: %w", err)
+	}
+	return nil
+}
+
+// RequestPasswordReset mails a reset link if an account uses the email
+// address. It never reports whether the address is known.
+func (userService *UserService) RequestPasswordReset(email string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RequestPasswordReset Service")
+	if email == "" {
+		return ErrInvalidUserInput
+	}
+	var user model.User
+	tx := initializers.DB.Where("LOWER(email) = LOWER(?)", email).First(&user)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			l.Debug().Msg("Password reset requested for unknown email")
+			return nil
+		}
+		return fmt.Errorf("requesting password reset: %w", err)
+	}
+	if user.Disabled {
+		l.Debug().Uint("userID", user.ID).Msg("Password reset requested for disabled account")
+		return nil
+	}
+	if user.OIDCSubject != nil {
+		l.Debug().Uint("userID", user.ID).Msg("Password reset requested for single sign-on account")
+		return nil
+	}
+
+	token, err := userService.issueUserToken(user.ID, model.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return fmt.Errorf("requesting password reset: %w", err)
+	}
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nSomeone asked to reset the password of your account. If it was you, open the link below:\n\n%s/reset-password?token=%s\n\nThe link expires in %d minutes. If you did not ask for this, you can ignore this email.\n",
+			user.Name, frontendURL(), token, int(passwordResetTTL.Minutes())),
+	}
+	if err := mailer.Get().Send(ctx, msg); err != nil {
+		return fmt.Errorf("requesting password reset: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword redeems a reset token, sets the new password and logs the
+// user out everywhere.
+func (userService *UserService) ResetPassword(token, newPassword string, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ResetPassword Service")
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		userToken, err := userService.consumeUserToken(tx, token, model.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+		var user model.User
+		if err := tx.First(&user, userToken.UserID).Error; err != nil {
+			return err
+		}
+		if err := userService.setPassword(tx, &user, newPassword); err != nil {
+			return err
+		}
+		// The reset link proves the user controls the mailbox.
+		return tx.Model(&user).
+			Update("email_verified_at", gorm.Expr("COALESCE(email_verified_at, ?)", time.Now())).Error
+	})
+	if err != nil {
+		return fmt.Errorf("resetting password: %w", err)
+	}
+	return nil
+}
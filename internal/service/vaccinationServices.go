@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type VaccinationNotFoundError struct {
+	ID uint
+}
+
+func (e VaccinationNotFoundError) Error() string {
+	return fmt.Sprintf("vaccination with ID %d not found", e.ID)
+}
+
+var ErrVaccineRequired = errors.New("vaccine is required")
+var ErrVaccinationInFuture = errors.New("given_at cannot be in the future")
+var ErrNextDueBeforeGiven = errors.New("next_due_at must be after given_at")
+var ErrAdministeredByNotStaff = errors.New("administered_by_id must be a clinic staff member")
+
+// VaccinationParams is the body of a dose. On update, an empty lot_number
+// and a null next_due_at clear the value instead of keeping it.
+type VaccinationParams struct {
+	Vaccine          string       `json:"vaccine" example:"Rabies"`
+	LotNumber        *string      `json:"lot_number" example:"RB-2291A"`
+	GivenAt          *time.Time   `json:"given_at" example:"2026-10-01T10:00:00Z"`
+	AdministeredByID *uint        `json:"administered_by_id" example:"2"`
+	NextDueAt        NullableTime `json:"next_due_at" swaggertype:"string" format:"date-time" example:"2027-10-01T00:00:00Z"`
+}
+
+// NullableTime is a time in a request body that tells an explicit null apart
+// from a field that was left out.
+type NullableTime struct {
+	Set  bool
+	Time *time.Time
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Time = nil
+		return nil
+	}
+	return json.Unmarshal(data, &t.Time)
+}
+
+// DueVaccination is the latest dose of a vaccine for a pet whose booster is
+// due within the requested window or already overdue.
+type DueVaccination struct {
+	Pet         model.Pet         `json:"pet"`
+	Vaccination model.Vaccination `json:"vaccination"`
+	Overdue     bool              `json:"overdue" example:"false"`
+}
+
+func (vaccinationService *VaccinationService) ListVaccinations(petID uint, ctx context.Context) ([]model.Vaccination, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside ListVaccinations Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return nil, fmt.Errorf("listing vaccinations of pet %d: %w", petID, err)
+	}
+	vaccinations := []model.Vaccination{}
+	tx := initializers.DB.Where("pet_id = ?", petID).Order("given_at DESC").Find(&vaccinations)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("listing vaccinations of pet %d: %w", petID, tx.Error)
+	}
+	return vaccinations, nil
+}
+
+func (vaccinationService *VaccinationService) GetVaccination(petID, id uint, ctx context.Context) (model.Vaccination, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetVaccination Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.Vaccination{}, fmt.Errorf("getting vaccination %d: %w", id, err)
+	}
+	var vaccination model.Vaccination
+	tx := initializers.DB.Where("pet_id = ?", petID).First(&vaccination, id)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Vaccination{}, VaccinationNotFoundError{ID: id}
+		}
+		return model.Vaccination{}, fmt.Errorf("getting vaccination %d: %w", id, err)
+	}
+	return vaccination, nil
+}
+
+// AddVaccination records a dose given to a pet. The dose defaults to now and
+// to the current user as the administering staff member.
+func (vaccinationService *VaccinationService) AddVaccination(petID uint, params VaccinationParams, ctx context.Context) (model.Vaccination, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AddVaccination Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.Vaccination{}, fmt.Errorf("adding vaccination: %w", err)
+	}
+	vaccination := model.Vaccination{
+		PetID:            petID,
+		GivenAt:          time.Now(),
+		AdministeredByID: params.AdministeredByID,
+	}
+	if vaccination.AdministeredByID == nil {
+		userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+		vaccination.AdministeredByID = &userID
+	}
+	applyVaccinationParams(&vaccination, params)
+	if err := validateVaccination(&vaccination); err != nil {
+		return model.Vaccination{}, fmt.Errorf("adding vaccination: %w", err)
+	}
+	if err := initializers.DB.Create(&vaccination).Error; err != nil {
+		return model.Vaccination{}, fmt.Errorf("adding vaccination: %w", err)
+	}
+	return vaccination, nil
+}
+
+// UpdateVaccination corrects a recorded dose. Fields left out keep their
+// current value; an empty lot number or a null next due date clears it.
+func (vaccinationService *VaccinationService) UpdateVaccination(petID, id uint, params VaccinationParams, ctx context.Context) (model.Vaccination, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside UpdateVaccination Service")
+	vaccination, err := vaccinationService.GetVaccination(petID, id, ctx)
+	if err != nil {
+		return model.Vaccination{}, fmt.Errorf("updating vaccination %d: %w", id, err)
+	}
+	if params.AdministeredByID != nil {
+		vaccination.AdministeredByID = params.AdministeredByID
+	}
+	if params.Vaccine == "" {
+		params.Vaccine = vaccination.Vaccine
+	}
+	applyVaccinationParams(&vaccination, params)
+	if err := validateVaccination(&vaccination); err != nil {
+		return model.Vaccination{}, fmt.Errorf("updating vaccination %d: %w", id, err)
+	}
+	if err := initializers.DB.Save(&vaccination).Error; err != nil {
+		return model.Vaccination{}, fmt.Errorf("updating vaccination %d: %w", id, err)
+	}
+	return vaccination, nil
+}
+
+func (vaccinationService *VaccinationService) DeleteVaccination(petID, id uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeleteVaccination Service")
+	vaccination, err := vaccinationService.GetVaccination(petID, id, ctx)
+	if err != nil {
+		return fmt.Errorf("deleting vaccination %d: %w", id, err)
+	}
+	if err := initializers.DB.Delete(&vaccination).Error; err != nil {
+		return fmt.Errorf("deleting vaccination %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetDueVaccinations lists, soonest first, every active pet whose latest dose
+// of a vaccine has its next dose due before now+within. Overdue doses are
+// always included; a dose superseded by a later one of the same vaccine is
+// not, and neither are deceased, transferred or archived pets.
+func (vaccinationService *VaccinationService) GetDueVaccinations(within time.Duration, ctx context.Context) ([]DueVaccination, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetDueVaccinations Service")
+	now := time.Now()
+	latest := initializers.DB.Model(&model.Vaccination{}).
+		Select("DISTINCT ON (pet_id, LOWER(vaccine)) *").
+		Where("pet_id IN (?)", initializers.DB.Model(&model.Pet{}).Select("id").Where("status = ?", model.PetStatusActive)).
+		Order("pet_id, LOWER(vaccine), given_at DESC, id DESC")
+	var vaccinations []model.Vaccination
+	tx := withPetAlerts(initializers.DB.Table("(?) AS latest", latest), "Pet.Alerts").
+		Where("next_due_at IS NOT NULL AND next_due_at <= ?", now.Add(within)).
+		Order("next_due_at ASC").
+		Find(&vaccinations)
+	if tx.Error != nil {
+		return nil, fmt.Errorf("getting due vaccinations: %w", tx.Error)
+	}
+	due := make([]DueVaccination, 0, len(vaccinations))
+	for _, vaccination := range vaccinations {
+		due = append(due, DueVaccination{
+			Pet:         vaccination.Pet,
+			Vaccination: vaccination,
+			Overdue:     vaccination.NextDueAt.Before(now),
+		})
+	}
+	return due, nil
+}
+
+func applyVaccinationParams(vaccination *model.Vaccination, params VaccinationParams) {
+	vaccination.Vaccine = strings.TrimSpace(params.Vaccine)
+	if params.LotNumber != nil {
+		vaccination.LotNumber = strings.TrimSpace(*params.LotNumber)
+	}
+	if params.GivenAt != nil {
+		vaccination.GivenAt = *params.GivenAt
+	}
+	if params.NextDueAt.Set {
+		vaccination.NextDueAt = params.NextDueAt.Time
+	}
+}
+
+func validateVaccination(vaccination *model.Vaccination) error {
+	if vaccination.Vaccine == "" {
+		return ErrVaccineRequired
+	}
+	if vaccination.GivenAt.After(time.Now()) {
+		return ErrVaccinationInFuture
+	}
+	if vaccination.NextDueAt != nil && !vaccination.NextDueAt.After(vaccination.GivenAt) {
+		return ErrNextDueBeforeGiven
+	}
+	if vaccination.AdministeredByID != nil {
+		var administeredBy model.User
+		if err := initializers.DB.First(&administeredBy, *vaccination.AdministeredByID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAdministeredByNotStaff
+			}
+			return err
+		}
+		if administeredBy.Role == model.UserTypeOwner {
+			return ErrAdministeredByNotStaff
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type VisitNoteNotFoundError struct {
+	AppointmentID uint
+}
+
+func (e VisitNoteNotFoundError) Error() string {
+	return fmt.Sprintf("no visit note for appointment %d", e.AppointmentID)
+}
+
+var ErrVisitNoteSigned = errors.New("visit note is signed and can no longer be edited, add an addendum instead")
+var ErrVisitNoteNotSigned = errors.New("visit note is not signed yet, edit it instead")
+var ErrVisitNoteIncomplete = errors.New("a visit note needs an assessment and a plan before it can be signed")
+var ErrAddendumTextRequired = errors.New("addendum text is required")
+
+type VisitNoteParams struct {
+	Subjective string       `json:"subjective" example:"Owner reports limping on the right hind leg for two days"`
+	Objective  string       `json:"objective" example:"Mild swelling of the right stifle, pain on flexion"`
+	Assessment string       `json:"assessment" example:"Suspected soft tissue injury"`
+	Plan       string       `json:"plan" example:"Rest for one week, recheck in 10 days"`
+	Vitals     model.Vitals `json:"vitals"`
+	Diagnoses  []string     `json:"diagnoses" example:"Soft tissue injury"`
+}
+
+type AddendumParams struct {
+	Text string `json:"text" example:"Correction: carprofen dose is 2 mg/kg once daily"`
+}
+
+// VisitSummary is the part of a signed visit note shown to the pet's owner.
+// The clinical reasoning in the subjective, objective and assessment sections
+// and the addenda are left out.
+type VisitSummary struct {
+	AppointmentID uint         `json:"appointment_id" example:"12"`
+	Slot          time.Time    `json:"slot"`
+	PetID         uint         `json:"pet_id" example:"3"`
+	Vitals        model.Vitals `json:"vitals"`
+	Diagnoses     []string     `json:"diagnoses" example:"Soft tissue injury"`
+	Plan          string       `json:"plan" example:"Rest for one week, recheck in 10 days"`
+	SignedAt      time.Time    `json:"signed_at"`
+}
+
+// GetVisitNote returns the full visit note of an appointment with its addenda.
+func (visitNoteService *VisitNoteService) GetVisitNote(appointmentID uint, ctx context.Context) (model.VisitNote, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetVisitNote Service")
+	appointmentService := &AppointmentService{}
+	if _, err := appointmentService.GetAppointment(appointmentID, ctx); err != nil {
+		return model.VisitNote{}, fmt.Errorf("getting visit note: %w", err)
+	}
+	return findVisitNote(initializers.DB, appointmentID)
+}
+
+// SaveVisitNote creates or replaces the draft visit note of an appointment.
+func (visitNoteService *VisitNoteService) SaveVisitNote(appointmentID uint, params VisitNoteParams, ctx context.Context) (model.VisitNote, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SaveVisitNote Service")
+	appointmentService := &AppointmentService{}
+	if _, err := appointmentService.GetAppointment(appointmentID, ctx); err != nil {
+		return model.VisitNote{}, fmt.Errorf("saving visit note: %w", err)
+	}
+	if err := validateVitals(params.Vitals); err != nil {
+		return model.VisitNote{}, fmt.Errorf("saving visit note: %w", err)
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+
+	var note model.VisitNote
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		existing, err := findVisitNote(tx, appointmentID)
+		if err != nil && !errors.As(err, &VisitNoteNotFoundError{}) {
+			return err
+		}
+		if err == nil && existing.SignedAt != nil {
+			return ErrVisitNoteSigned
+		}
+		note = existing
+		if err != nil {
+			note = model.VisitNote{AppointmentID: appointmentID, AuthorID: userID, Addenda: []model.VisitNoteAddendum{}}
+		}
+		note.Subjective = strings.TrimSpace(params.Subjective)
+		note.Objective = strings.TrimSpace(params.Objective)
+		note.Assessment = strings.TrimSpace(params.Assessment)
+		note.Plan = strings.TrimSpace(params.Plan)
+		note.Vitals = params.Vitals
+		note.Diagnoses = cleanDiagnoses(params.Diagnoses)
+		if note.ID == 0 {
+			return tx.Create(&note).Error
+		}
+		result := tx.Model(&note).Where("signed_at IS NULL").
+			Select("subjective", "objective", "assessment", "plan", "weight_kg", "body_condition_score", "temperature_c", "heart_rate_bpm", "respiratory_rate_bpm", "diagnoses").
+			Updates(&note)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVisitNoteSigned
+		}
+		return nil
+	})
+	if err != nil {
+		return model.VisitNote{}, fmt.Errorf("saving visit note: %w", err)
+	}
+	return note, nil
+}
+
+// SignVisitNote locks the visit note of an appointment and adds its vitals
+// to the pet's vitals history.
+func (visitNoteService *VisitNoteService) SignVisitNote(appointmentID uint, ctx context.Context) (model.VisitNote, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside SignVisitNote Service")
+	appointmentService := &AppointmentService{}
+	appointment, err := appointmentService.GetAppointment(appointmentID, ctx)
+	if err != nil {
+		return model.VisitNote{}, fmt.Errorf("signing visit note: %w", err)
+	}
+	note, err := findVisitNote(initializers.DB, appointmentID)
+	if err != nil {
+		return model.VisitNote{}, fmt.Errorf("signing visit note: %w", err)
+	}
+	if note.SignedAt != nil {
+		return model.VisitNote{}, ErrVisitNoteSigned
+	}
+	if note.Assessment == "" || note.Plan == "" {
+		return model.VisitNote{}, ErrVisitNoteIncomplete
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	now := time.Now()
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&note).Where("signed_at IS NULL").
+			Updates(map[string]interface{}{"signed_at": now, "signed_by_id": userID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVisitNoteSigned
+		}
+		if !hasVitals(note.Vitals) {
+			return nil
+		}
+		measurement := model.VitalsMeasurement{
+			PetID:        appointment.PetID,
+			MeasuredAt:   appointment.Slot,
+			Vitals:       note.Vitals,
+			RecordedByID: note.AuthorID,
+			VisitNoteID:  &note.ID,
+		}
+		return tx.Create(&measurement).Error
+	})
+	if err != nil {
+		return model.VisitNote{}, fmt.Errorf("signing visit note: %w", err)
+	}
+	note.SignedAt = &now
+	note.SignedByID = &userID
+	return note, nil
+}
+
+// AddAddendum appends a correction to a signed visit note.
+func (visitNoteService *VisitNoteService) AddAddendum(appointmentID uint, params AddendumParams, ctx context.Context) (model.VisitNoteAddendum, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside AddAddendum Service")
+	note, err := visitNoteService.GetVisitNote(appointmentID, ctx)
+	if err != nil {
+		return model.VisitNoteAddendum{}, fmt.Errorf("adding addendum: %w", err)
+	}
+	if note.SignedAt == nil {
+		return model.VisitNoteAddendum{}, ErrVisitNoteNotSigned
+	}
+	text := strings.TrimSpace(params.Text)
+	if text == "" {
+		return model.VisitNoteAddendum{}, ErrAddendumTextRequired
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	addendum := model.VisitNoteAddendum{VisitNoteID: note.ID, AuthorID: userID, Text: text}
+	if err := initializers.DB.Create(&addendum).Error; err != nil {
+		return model.VisitNoteAddendum{}, fmt.Errorf("adding addendum: %w", err)
+	}
+	return addendum, nil
+}
+
+// GetVisitSummary returns the owner facing summary of a signed visit note.
+// Drafts are reported as not found.
+func (visitNoteService *VisitNoteService) GetVisitSummary(appointmentID uint, ctx context.Context) (VisitSummary, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetVisitSummary Service")
+	appointmentService := &AppointmentService{}
+	appointment, err := appointmentService.GetAppointment(appointmentID, ctx)
+	if err != nil {
+		return VisitSummary{}, fmt.Errorf("getting visit summary: %w", err)
+	}
+	note, err := findVisitNote(initializers.DB, appointmentID)
+	if err != nil {
+		return VisitSummary{}, fmt.Errorf("getting visit summary: %w", err)
+	}
+	if note.SignedAt == nil {
+		return VisitSummary{}, VisitNoteNotFoundError{AppointmentID: appointmentID}
+	}
+	return VisitSummary{
+		AppointmentID: appointmentID,
+		Slot:          appointment.Slot,
+		PetID:         appointment.PetID,
+		Vitals:        note.Vitals,
+		Diagnoses:     note.Diagnoses,
+		Plan:          note.Plan,
+		SignedAt:      *note.SignedAt,
+	}, nil
+}
+
+func findVisitNote(db *gorm.DB, appointmentID uint) (model.VisitNote, error) {
+	var note model.VisitNote
+	tx := db.Preload("Addenda", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("appointment_id = ?", appointmentID).First(&note)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.VisitNote{}, VisitNoteNotFoundError{AppointmentID: appointmentID}
+		}
+		return model.VisitNote{}, fmt.Errorf("getting visit note of appointment %d: %w", appointmentID, err)
+	}
+	return note, nil
+}
+
+func cleanDiagnoses(diagnoses []string) []string {
+	cleaned := []string{}
+	for _, diagnosis := range diagnoses {
+		if diagnosis = strings.TrimSpace(diagnosis); diagnosis != "" {
+			cleaned = append(cleaned, diagnosis)
+		}
+	}
+	return cleaned
+}
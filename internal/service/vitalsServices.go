@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
+	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+type VitalsMeasurementNotFoundError struct {
+	ID uint
+}
+
+func (e VitalsMeasurementNotFoundError) Error() string {
+	return fmt.Sprintf("vitals measurement with ID %d not found", e.ID)
+}
+
+var ErrInvalidVitals = errors.New("vitals must be positive numbers and the body condition score between 1 and 9")
+var ErrNoVitals = errors.New("at least one vital sign is required")
+var ErrMeasurementInFuture = errors.New("measured_at cannot be in the future")
+var ErrMeasurementFromVisitNote = errors.New("this measurement comes from a signed visit note, add an addendum to the note instead")
+
+// A pet losing this share of its weight within rapidWeightLossWindow is
+// flagged for the vet.
+const (
+	rapidWeightLossPercent = 5.0
+	rapidWeightLossWindow  = 30 * 24 * time.Hour
+)
+
+type VitalsParams struct {
+	MeasuredAt *time.Time   `json:"measured_at" example:"2026-10-01T10:00:00Z"`
+	Vitals     model.Vitals `json:"vitals"`
+}
+
+type VitalsSeriesParams struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// VitalsTrend summarizes the weight readings of a series. Percentages are
+// nil when there are not enough readings to compare.
+type VitalsTrend struct {
+	FirstWeightKg             *float64 `json:"first_weight_kg" example:"30.2"`
+	LatestWeightKg            *float64 `json:"latest_weight_kg" example:"28.4"`
+	WeightChangePercent       *float64 `json:"weight_change_percent" example:"-6"`
+	RecentWeightChangePercent *float64 `json:"recent_weight_change_percent" example:"-5.3"`
+	RapidWeightLoss           bool     `json:"rapid_weight_loss" example:"true"`
+}
+
+type VitalsSeries struct {
+	PetID        uint                      `json:"pet_id" example:"3"`
+	Measurements []model.VitalsMeasurement `json:"measurements"`
+	Trend        VitalsTrend               `json:"trend"`
+}
+
+// GetVitalsSeries returns a pet's vitals, oldest first, with a trend over
+// the returned readings.
+func (vitalsService *VitalsService) GetVitalsSeries(petID uint, params VitalsSeriesParams, ctx context.Context) (VitalsSeries, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside GetVitalsSeries Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return VitalsSeries{}, fmt.Errorf("getting vitals of pet %d: %w", petID, err)
+	}
+	query := initializers.DB.Where("pet_id = ?", petID)
+	if params.From != nil {
+		query = query.Where("measured_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("measured_at <= ?", *params.To)
+	}
+	series := VitalsSeries{PetID: petID, Measurements: []model.VitalsMeasurement{}}
+	if err := query.Order("measured_at ASC, id ASC").Find(&series.Measurements).Error; err != nil {
+		return VitalsSeries{}, fmt.Errorf("getting vitals of pet %d: %w", petID, err)
+	}
+	series.Trend = weightTrend(series.Measurements)
+	return series, nil
+}
+
+// RecordVitals adds a reading taken by the current user, now unless
+// measured_at says otherwise.
+func (vitalsService *VitalsService) RecordVitals(petID uint, params VitalsParams, ctx context.Context) (model.VitalsMeasurement, error) {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside RecordVitals Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return model.VitalsMeasurement{}, fmt.Errorf("recording vitals: %w", err)
+	}
+	if !hasVitals(params.Vitals) {
+		return model.VitalsMeasurement{}, ErrNoVitals
+	}
+	if err := validateVitals(params.Vitals); err != nil {
+		return model.VitalsMeasurement{}, err
+	}
+	measuredAt := time.Now()
+	if params.MeasuredAt != nil {
+		if params.MeasuredAt.After(measuredAt) {
+			return model.VitalsMeasurement{}, ErrMeasurementInFuture
+		}
+		measuredAt = *params.MeasuredAt
+	}
+	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
+	measurement := model.VitalsMeasurement{
+		PetID:        petID,
+		MeasuredAt:   measuredAt,
+		Vitals:       params.Vitals,
+		RecordedByID: userID,
+	}
+	if err := initializers.DB.Create(&measurement).Error; err != nil {
+		return model.VitalsMeasurement{}, fmt.Errorf("recording vitals: %w", err)
+	}
+	return measurement, nil
+}
+
+// DeleteVitals removes a reading entered by mistake. Readings from signed
+// visit notes are part of the clinical record and cannot be deleted.
+func (vitalsService *VitalsService) DeleteVitals(petID, id uint, ctx context.Context) error {
+	l := zerolog.Ctx(ctx)
+	l.Trace().Msg("Inside DeleteVitals Service")
+	petService := &PetService{}
+	if _, err := petService.GetPet(petID, ctx); err != nil {
+		return fmt.Errorf("deleting vitals %d: %w", id, err)
+	}
+	var measurement model.VitalsMeasurement
+	if err := initializers.DB.Where("pet_id = ?", petID).First(&measurement, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return VitalsMeasurementNotFoundError{ID: id}
+		}
+		return fmt.Errorf("deleting vitals %d: %w", id, err)
+	}
+	if measurement.VisitNoteID != nil {
+		return ErrMeasurementFromVisitNote
+	}
+	if err := initializers.DB.Delete(&measurement).Error; err != nil {
+		return fmt.Errorf("deleting vitals %d: %w", id, err)
+	}
+	return nil
+}
+
+// weightTrend compares the first and latest weight of the series, and the
+// latest weight with the heaviest one in the rapidWeightLossWindow before it.
+func weightTrend(measurements []model.VitalsMeasurement) VitalsTrend {
+	var trend VitalsTrend
+	var first, latest *model.VitalsMeasurement
+	for i := range measurements {
+		if measurements[i].Vitals.WeightKg == nil {
+			continue
+		}
+		if first == nil {
+			first = &measurements[i]
+		}
+		latest = &measurements[i]
+	}
+	if latest == nil {
+		return trend
+	}
+	trend.FirstWeightKg = first.Vitals.WeightKg
+	trend.LatestWeightKg = latest.Vitals.WeightKg
+	if first == latest {
+		return trend
+	}
+	trend.WeightChangePercent = percentChange(*first.Vitals.WeightKg, *latest.Vitals.WeightKg)
+
+	peak := 0.0
+	windowStart := latest.MeasuredAt.Add(-rapidWeightLossWindow)
+	for _, measurement := range measurements {
+		weight := measurement.Vitals.WeightKg
+		if weight == nil || measurement.MeasuredAt.Before(windowStart) || measurement.ID == latest.ID {
+			continue
+		}
+		peak = math.Max(peak, *weight)
+	}
+	if peak > 0 {
+		trend.RecentWeightChangePercent = percentChange(peak, *latest.Vitals.WeightKg)
+		trend.RapidWeightLoss = *trend.RecentWeightChangePercent <= -rapidWeightLossPercent
+	}
+	return trend
+}
+
+func percentChange(from, to float64) *float64 {
+	change := math.Round((to-from)/from*1000) / 10
+	return &change
+}
+
+func hasVitals(vitals model.Vitals) bool {
+	return vitals.WeightKg != nil || vitals.BodyConditionScore != nil || vitals.TemperatureC != nil ||
+		vitals.HeartRateBPM != nil || vitals.RespiratoryRateBPM != nil
+}
+
+func validateVitals(vitals model.Vitals) error {
+	if vitals.WeightKg != nil && *vitals.WeightKg <= 0 {
+		return ErrInvalidVitals
+	}
+	if vitals.BodyConditionScore != nil && (*vitals.BodyConditionScore < 1 || *vitals.BodyConditionScore > 9) {
+		return ErrInvalidVitals
+	}
+	if vitals.TemperatureC != nil && *vitals.TemperatureC <= 0 {
+		return ErrInvalidVitals
+	}
+	if vitals.HeartRateBPM != nil && *vitals.HeartRateBPM <= 0 {
+		return ErrInvalidVitals
+	}
+	if vitals.RespiratoryRateBPM != nil && *vitals.RespiratoryRateBPM <= 0 {
+		return ErrInvalidVitals
+	}
+	return nil
+}
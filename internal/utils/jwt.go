@@ -2,33 +2,38 @@ package utils
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var secretKey = []byte(os.Getenv("JWT_SECRET"))
+// AccessTokenTTL is how long an access token stays valid. Clients renew it
+// with the refresh token issued alongside it.
+const AccessTokenTTL = time.Minute * 5
 
-func GenerateJWT(userID uint, username, role string) (string, error) {
+func GenerateJWT(userID, sessionID uint, username, role string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":  userID,
+		"sid":      sessionID,
 		"username": username,
 		"role":     role,
-		"exp":      time.Now().Add(time.Minute * 5).Unix(),
+		"exp":      time.Now().Add(AccessTokenTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	set, err := currentKeySet()
+	if err != nil {
+		return "", err
+	}
+	return set.sign(claims)
 }
 
 func ParseJWT(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return secretKey, nil
-	})
+	set, err := currentKeySet()
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.Parse(tokenString, set.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -41,3 +46,24 @@ func ParseJWT(tokenString string) (jwt.MapClaims, error) {
 		return nil, fmt.Errorf("invalid token")
 	}
 }
+
+// GenerateImpersonationJWT issues an access token that acts as an owner on
+// behalf of a staff member. It is bound to the staff member's session and to
+// the impersonation record, so ending either invalidates it.
+func GenerateImpersonationJWT(userID, sessionID, impersonationID, impersonatorID uint, username, role string, ttl time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":         userID,
+		"sid":             sessionID,
+		"username":        username,
+		"role":            role,
+		"imp":             impersonationID,
+		"impersonator_id": impersonatorID,
+		"exp":             time.Now().Add(ttl).Unix(),
+	}
+
+	set, err := currentKeySet()
+	if err != nil {
+		return "", err
+	}
+	return set.sign(claims)
+}
@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrNoSigningKey = errors.New("no JWT signing key configured")
+
+// signingKey is one entry of the key set. Retired keys only carry the public
+// half and are kept so that tokens signed before a rotation stay valid.
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet holds every key tokens may be verified with and the one new tokens
+// are signed with.
+type KeySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty" example:"OKP"`
+	Kid string `json:"kid" example:"2026-10"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"EdDSA"`
+	Crv string `json:"crv,omitempty" example:"Ed25519"`
+	X   string `json:"x,omitempty" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	keySetMu sync.RWMutex
+	keySet   *KeySet
+)
+
+// LoadKeys reads the PEM files in JWT_KEYS_DIR (default "keys"). Each file
+// name without its .pem extension is the kid. Files holding a private key can
+// sign; files holding only a public key are accepted for verification.
+// JWT_ACTIVE_KID selects the signing key and may be omitted when there is a
+// single private key. LoadKeys fails when no signing key is found.
+func LoadKeys() error {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		dir = "keys"
+	}
+	set, err := LoadKeySet(dir, os.Getenv("JWT_ACTIVE_KID"))
+	if err != nil {
+		return err
+	}
+	SetKeySet(set)
+	return nil
+}
+
+// LoadKeySet builds a KeySet from the PEM files in dir.
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	set := &KeySet{keys: map[string]*signingKey{}}
+	var signers []*signingKey
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		key, err := parseSigningKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("loading JWT key %s: %w", file, err)
+		}
+		set.keys[kid] = key
+		if key.private != nil {
+			signers = append(signers, key)
+		}
+	}
+
+	switch {
+	case activeKID != "":
+		key, ok := set.keys[activeKID]
+		if !ok || key.private == nil {
+			return nil, fmt.Errorf("%w: no private key with kid %q in %s", ErrNoSigningKey, activeKID, dir)
+		}
+		set.active = key
+	case len(signers) == 1:
+		set.active = signers[0]
+	case len(signers) == 0:
+		return nil, fmt.Errorf("%w: no private key in %s", ErrNoSigningKey, dir)
+	default:
+		return nil, fmt.Errorf("%w: several private keys in %s, set JWT_ACTIVE_KID", ErrNoSigningKey, dir)
+	}
+	return set, nil
+}
+
+// SetKeySet replaces the process wide key set.
+func SetKeySet(set *KeySet) {
+	keySetMu.Lock()
+	defer keySetMu.Unlock()
+	keySet = set
+}
+
+func currentKeySet() (*KeySet, error) {
+	keySetMu.RLock()
+	defer keySetMu.RUnlock()
+	if keySet == nil || keySet.active == nil {
+		return nil, ErrNoSigningKey
+	}
+	return keySet, nil
+}
+
+func parseSigningKey(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key := &signingKey{kid: kid}
+	switch block.Type {
+	case "PRIVATE KEY", "RSA PRIVATE KEY":
+		var parsed interface{}
+		var err error
+		if block.Type == "RSA PRIVATE KEY" {
+			parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		} else {
+			parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		key.private = signer
+		key.public = signer.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.public = parsed
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch pub := key.public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+	return key, nil
+}
+
+func (set *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(set.active.method, claims)
+	token.Header["kid"] = set.active.kid
+	return token.SignedString(set.active.private)
+}
+
+// keyFunc picks the verification key by the token's kid and rejects tokens
+// whose alg does not match that key.
+func (set *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := set.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// JWKS returns the public halves of all keys, sorted by kid.
+func (set *KeySet) JWKS() JWKS {
+	kids := make([]string, 0, len(set.keys))
+	for kid := range set.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	jwks := JWKS{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		key := set.keys[kid]
+		jwk := JWK{Kid: kid, Use: "sig", Alg: key.method.Alg()}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// PublicJWKS returns the JSON Web Key Set of the loaded keys.
+func PublicJWKS() (JWKS, error) {
+	set, err := currentKeySet()
+	if err != nil {
+		return JWKS{}, err
+	}
+	return set.JWKS(), nil
+}
@@ -0,0 +1,24 @@
+package validators
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+var ErrInvalidEmail = errors.New("email must be a single plain address such as jane@example.com")
+
+// NormalizeEmail validates an email address as it is stored on an account
+// and returns it without surrounding spaces. Display names, address lists
+// and line breaks are rejected, since the address ends up in mail headers.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if strings.ContainsAny(email, "\r\n") {
+		return "", ErrInvalidEmail
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
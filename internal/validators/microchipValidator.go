@@ -0,0 +1,28 @@
+package validators
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidMicrochip = errors.New("microchip number must have 15 digits as defined by ISO 11784/11785")
+
+// NormalizeMicrochip validates an ISO 11784/11785 microchip number and
+// returns it without the spaces or dashes scanners and certificates often
+// print. The first three digits are an ISO 3166 country code or, from 900 on,
+// a manufacturer code; 000 is unassigned and 999 marks test transponders.
+func NormalizeMicrochip(number string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(number))
+	if len(normalized) != 15 {
+		return "", ErrInvalidMicrochip
+	}
+	for _, r := range normalized {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidMicrochip
+		}
+	}
+	if prefix := normalized[:3]; prefix == "000" || prefix == "999" {
+		return "", ErrInvalidMicrochip
+	}
+	return normalized, nil
+}
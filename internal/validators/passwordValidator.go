@@ -0,0 +1,113 @@
+package validators
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//go:embed commonPasswords.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return set
+}()
+
+type PasswordPolicyError struct {
+	Reason string
+}
+
+func (e PasswordPolicyError) Error() string {
+	return "password does not meet the policy: " + e.Reason
+}
+
+// PasswordPolicy is the set of rules every new password must satisfy, at
+// signup as well as on change and reset.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	RejectCommon  bool
+	// HistorySize is how many previous passwords may not be reused.
+	HistorySize int
+}
+
+// DefaultPasswordPolicy is read from the PASSWORD_* environment variables.
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:     intFromEnv("PASSWORD_MIN_LENGTH", 8),
+	RequireUpper:  boolFromEnv("PASSWORD_REQUIRE_UPPER", true),
+	RequireLower:  boolFromEnv("PASSWORD_REQUIRE_LOWER", true),
+	RequireDigit:  boolFromEnv("PASSWORD_REQUIRE_DIGIT", true),
+	RequireSymbol: boolFromEnv("PASSWORD_REQUIRE_SYMBOL", false),
+	RejectCommon:  boolFromEnv("PASSWORD_REJECT_COMMON", true),
+	HistorySize:   intFromEnv("PASSWORD_HISTORY_SIZE", 5),
+}
+
+// ValidatePassword checks a password against the default policy.
+func ValidatePassword(password string) error {
+	return DefaultPasswordPolicy.Validate(password)
+}
+
+func (p PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return PasswordPolicyError{Reason: fmt.Sprintf("must be at least %d characters long", p.MinLength)}
+	}
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		return PasswordPolicyError{Reason: "must contain an upper case letter"}
+	}
+	if p.RequireLower && !hasLower {
+		return PasswordPolicyError{Reason: "must contain a lower case letter"}
+	}
+	if p.RequireDigit && !hasDigit {
+		return PasswordPolicyError{Reason: "must contain a digit"}
+	}
+	if p.RequireSymbol && !hasSymbol {
+		return PasswordPolicyError{Reason: "must contain a symbol"}
+	}
+	if p.RejectCommon {
+		if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+			return PasswordPolicyError{Reason: "is too common"}
+		}
+	}
+	return nil
+}
+
+func intFromEnv(key string, fallback int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || v < 0 {
+		return fallback
+	}
+	return v
+}
+
+func boolFromEnv(key string, fallback bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
@@ -2,9 +2,13 @@ package validators
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/MSaiAswin/pet-clinic-management-system/cmd/initializers"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/middleware"
 	"github.com/MSaiAswin/pet-clinic-management-system/internal/model"
+	"gorm.io/gorm"
 )
 
 type ResourceNotOwnedError struct {
@@ -14,11 +18,40 @@ func (e ResourceNotOwnedError) Error() string {
 	return "requested resource is not owned by the user"
 }
 
-func ValidateResourceOwner(resourceOwnerID uint, ctx context.Context) error {
+// Owner describes who a resource belongs to: a single user and, for a pet
+// shared with a household, the household's members.
+type Owner struct {
+	UserID      uint
+	HouseholdID *uint
+}
+
+// PetOwner returns the owner of a pet, including the household it is shared
+// with.
+func PetOwner(pet model.Pet) Owner {
+	return Owner{UserID: pet.OwnerID, HouseholdID: pet.HouseholdID}
+}
+
+// ValidateResourceOwner allows access to a resource owned by the current user,
+// to members of its household whose access level covers access, or to anyone
+// else's if the user's role grants permission.
+func ValidateResourceOwner(owner Owner, access string, permission string, ctx context.Context) error {
 	userID, _ := ctx.Value(middleware.ContextKeyUserID).(uint)
-	role, _ := ctx.Value(middleware.ContextKeyRole).(string)
-	if userID != resourceOwnerID && role == model.UserTypeOwner {
+	if userID == owner.UserID || middleware.HasPermission(ctx, permission) {
+		return nil
+	}
+	if owner.HouseholdID == nil || access == "" {
+		return ResourceNotOwnedError{}
+	}
+	var member model.HouseholdMember
+	tx := initializers.DB.Where("household_id = ? AND user_id = ?", *owner.HouseholdID, userID).First(&member)
+	if err := tx.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ResourceNotOwnedError{}
+		}
+		return fmt.Errorf("checking household membership: %w", err)
+	}
+	if !model.HouseholdAccessCovers(member.Access, access) {
 		return ResourceNotOwnedError{}
 	}
 	return nil
-}
\ No newline at end of file
+}